@@ -0,0 +1,24 @@
+// Package bulk streams wellally resources to and from NDJSON files
+// following the FHIR Bulk Data Access convention: one file per resource
+// type, one JSON object per line, gzip-optional.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+//
+// Writer dispatches Write(resource any) by concrete type into the
+// matching "<ResourceType>.ndjson[.gz]" stream. Reader is the inverse:
+// it yields one typed, unbuffered channel per resource type so a caller
+// can range over e.g. Persons() while each line is decoded on demand; a
+// directory source is read file by file, while a tar.gz source is read
+// into memory whole when the Reader is created. Both operate against a
+// target/source directory or a
+// single tar.gz archive, so an export can be handed to another system
+// as either a folder or one file. Manifest mirrors the `output[]` array
+// a FHIR Bulk Data kickoff response returns, recording the type, url,
+// and count written for each resource stream.
+//
+// This package is named bulk rather than io/bulk: every other wellally
+// domain package (fhir, pdq, ncpdp, terminology, dicomsr, pedigree)
+// lives directly under language/go, and bulk follows that same flat
+// layout for consistency.
+package bulk
@@ -0,0 +1,32 @@
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ManifestEntry is one entry of a FHIR Bulk Data kickoff response's
+// output[] array.
+type ManifestEntry struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Count int    `json:"count"`
+}
+
+// manifestDoc is the top-level shape WriteManifest emits, mirroring the
+// "output" array a FHIR Bulk Data $export response returns.
+type manifestDoc struct {
+	Output []ManifestEntry `json:"output"`
+}
+
+// WriteManifest writes entries to w as a FHIR Bulk Data manifest
+// document, e.g. for publishing alongside an export's NDJSON files.
+func WriteManifest(w io.Writer, entries []ManifestEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifestDoc{Output: entries}); err != nil {
+		return fmt.Errorf("bulk: writing manifest: %w", err)
+	}
+	return nil
+}
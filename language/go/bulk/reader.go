@@ -0,0 +1,153 @@
+package bulk
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// Reader streams wellally resources out of a directory or tar.gz
+// archive of "<Type>.ndjson[.gz]" files, one typed channel per resource
+// type. Each channel is fed by its own goroutine scanning its file line
+// by line, so a caller can range over e.g. Persons() without a directory
+// source's file being read into memory up front. A tar.gz source is read
+// into memory whole by NewReaderTarGz (see tarGzSource).
+type Reader struct {
+	src   source
+	files map[string]string // resource type -> file name present in src
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewReaderDir returns a Reader over the "<Type>.ndjson[.gz]" files
+// found directly under dir.
+func NewReaderDir(dir string) (*Reader, error) {
+	return newReader(newDirSource(dir))
+}
+
+// NewReaderTarGz returns a Reader over the "<Type>.ndjson[.gz]" files
+// found in the tar.gz archive at path.
+func NewReaderTarGz(path string) (*Reader, error) {
+	src, err := newTarGzSource(path)
+	if err != nil {
+		return nil, err
+	}
+	return newReader(src)
+}
+
+func newReader(src source) (*Reader, error) {
+	names, err := src.list()
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	for _, name := range names {
+		for _, typeName := range resourceTypes {
+			if name == typeName+".ndjson" || name == typeName+".ndjson.gz" {
+				files[typeName] = name
+			}
+		}
+	}
+	return &Reader{src: src, files: files}, nil
+}
+
+// Err returns the first error encountered by any stream, once its
+// channel has been fully drained. It is nil until then.
+func (r *Reader) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Reader) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// stream scans the NDJSON file for typeName, decoding each line into a
+// fresh T and sending it on the returned channel, which is closed once
+// the file is exhausted or an error occurs. A missing file yields a
+// channel that is immediately closed.
+func stream[T any](r *Reader, typeName string) <-chan T {
+	out := make(chan T)
+	fileName, ok := r.files[typeName]
+	if !ok {
+		close(out)
+		return out
+	}
+
+	go func() {
+		defer close(out)
+
+		rc, err := r.src.open(fileName)
+		if err != nil {
+			r.setErr(err)
+			return
+		}
+		defer rc.Close()
+
+		in := io.Reader(rc)
+		if strings.HasSuffix(fileName, ".gz") {
+			gz, err := gzip.NewReader(rc)
+			if err != nil {
+				r.setErr(fmt.Errorf("bulk: opening gzip stream in %s: %w", fileName, err))
+				return
+			}
+			defer gz.Close()
+			in = gz
+		}
+
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			var v T
+			if err := json.Unmarshal(line, &v); err != nil {
+				r.setErr(fmt.Errorf("bulk: decoding %s: %w", fileName, err))
+				return
+			}
+			out <- v
+		}
+		if err := scanner.Err(); err != nil {
+			r.setErr(fmt.Errorf("bulk: reading %s: %w", fileName, err))
+		}
+	}()
+
+	return out
+}
+
+// Persons streams the Person resources found in this Reader's source.
+func (r *Reader) Persons() <-chan wellally.Person { return stream[wellally.Person](r, TypePerson) }
+
+// LabReports streams the LabReport resources found in this Reader's source.
+func (r *Reader) LabReports() <-chan wellally.LabReport {
+	return stream[wellally.LabReport](r, TypeLabReport)
+}
+
+// ImagingReports streams the ImagingReport resources found in this Reader's source.
+func (r *Reader) ImagingReports() <-chan wellally.ImagingReport {
+	return stream[wellally.ImagingReport](r, TypeImagingReport)
+}
+
+// MedicationRecords streams the MedicationRecord resources found in this Reader's source.
+func (r *Reader) MedicationRecords() <-chan wellally.MedicationRecord {
+	return stream[wellally.MedicationRecord](r, TypeMedicationRecord)
+}
+
+// FamilyHealthTrees streams the FamilyHealthTree resources found in this Reader's source.
+func (r *Reader) FamilyHealthTrees() <-chan wellally.FamilyHealthTree {
+	return stream[wellally.FamilyHealthTree](r, TypeFamilyHealthTree)
+}
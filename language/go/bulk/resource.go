@@ -0,0 +1,46 @@
+package bulk
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// Resource type names used as NDJSON file stems, matching the struct
+// names in package wellally.
+const (
+	TypePerson           = "Person"
+	TypeLabReport        = "LabReport"
+	TypeImagingReport    = "ImagingReport"
+	TypeMedicationRecord = "MedicationRecord"
+	TypeFamilyHealthTree = "FamilyHealthTree"
+)
+
+// resourceTypes lists every TypeXxx constant, in the order new streams
+// are opened and the manifest is emitted.
+var resourceTypes = []string{
+	TypePerson,
+	TypeLabReport,
+	TypeImagingReport,
+	TypeMedicationRecord,
+	TypeFamilyHealthTree,
+}
+
+// resourceTypeFor returns the bulk resource type name bound to the
+// concrete type of v.
+func resourceTypeFor(v any) (string, error) {
+	switch v.(type) {
+	case wellally.Person, *wellally.Person:
+		return TypePerson, nil
+	case wellally.LabReport, *wellally.LabReport:
+		return TypeLabReport, nil
+	case wellally.ImagingReport, *wellally.ImagingReport:
+		return TypeImagingReport, nil
+	case wellally.MedicationRecord, *wellally.MedicationRecord:
+		return TypeMedicationRecord, nil
+	case wellally.FamilyHealthTree, *wellally.FamilyHealthTree:
+		return TypeFamilyHealthTree, nil
+	default:
+		return "", fmt.Errorf("bulk: no resource type registered for %T", v)
+	}
+}
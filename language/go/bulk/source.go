@@ -0,0 +1,104 @@
+package bulk
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// source abstracts where Reader finds its NDJSON streams: a plain
+// directory or a single tar.gz archive.
+type source interface {
+	// list returns the file names available to open.
+	list() ([]string, error)
+	// open returns the content of name.
+	open(name string) (io.ReadCloser, error)
+}
+
+// dirSource reads streams from files under a directory.
+type dirSource struct {
+	dir string
+}
+
+func newDirSource(dir string) source { return &dirSource{dir: dir} }
+
+func (s *dirSource) list() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: reading %s: %w", s.dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (s *dirSource) open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("bulk: opening %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// tarGzSource reads streams out of a tar.gz archive. The archive is
+// read fully into memory up front so that open can be called per
+// resource type in any order; bulk exports are expected to be one file
+// per resource type, not large enough on their own to make this costly.
+type tarGzSource struct {
+	files map[string][]byte
+	names []string
+}
+
+func newTarGzSource(path string) (source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: opening gzip stream in %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	src := &tarGzSource{files: make(map[string][]byte)}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bulk: reading tar entry in %s: %w", path, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("bulk: reading tar entry %s: %w", hdr.Name, err)
+		}
+		src.files[hdr.Name] = data
+		src.names = append(src.names, hdr.Name)
+	}
+	return src, nil
+}
+
+func (s *tarGzSource) list() ([]string, error) { return s.names, nil }
+
+func (s *tarGzSource) open(name string) (io.ReadCloser, error) {
+	data, ok := s.files[name]
+	if !ok {
+		return nil, fmt.Errorf("bulk: %s not found in archive", name)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
@@ -0,0 +1,104 @@
+package bulk
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// target abstracts where Writer puts its NDJSON streams: a plain
+// directory or a single tar.gz archive.
+type target interface {
+	// create opens name for writing, truncating any existing content.
+	create(name string) (io.WriteCloser, error)
+	// Close finalizes the target (e.g. flushing the tar/gzip trailers).
+	Close() error
+}
+
+// dirTarget writes each stream as its own file under a directory.
+type dirTarget struct {
+	dir string
+}
+
+// newDirTarget returns a target that writes files under dir, creating
+// dir if it does not already exist.
+func newDirTarget(dir string) (target, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("bulk: creating %s: %w", dir, err)
+	}
+	return &dirTarget{dir: dir}, nil
+}
+
+func (t *dirTarget) create(name string) (io.WriteCloser, error) {
+	f, err := os.Create(filepath.Join(t.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("bulk: creating %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func (t *dirTarget) Close() error { return nil }
+
+// tarGzTarget collects streams in memory and writes them as one tar.gz
+// archive on Close. Tar entries require a known size up front, so
+// unlike dirTarget this cannot stream each write straight to disk.
+type tarGzTarget struct {
+	out     io.WriteCloser
+	buffers map[string]*bytes.Buffer
+	order   []string
+}
+
+// newTarGzTarget returns a target that writes a single tar.gz archive
+// to path.
+func newTarGzTarget(path string) (target, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: creating %s: %w", path, err)
+	}
+	return &tarGzTarget{out: f, buffers: make(map[string]*bytes.Buffer)}, nil
+}
+
+type tarGzEntryWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w *tarGzEntryWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+func (w *tarGzEntryWriter) Close() error                { return nil }
+
+func (t *tarGzTarget) create(name string) (io.WriteCloser, error) {
+	buf, ok := t.buffers[name]
+	if !ok {
+		buf = &bytes.Buffer{}
+		t.buffers[name] = buf
+		t.order = append(t.order, name)
+	} else {
+		buf.Reset()
+	}
+	return &tarGzEntryWriter{buf: buf}, nil
+}
+
+func (t *tarGzTarget) Close() error {
+	gz := gzip.NewWriter(t.out)
+	tw := tar.NewWriter(gz)
+	for _, name := range t.order {
+		buf := t.buffers[name]
+		hdr := &tar.Header{Name: name, Size: int64(buf.Len()), Mode: 0o644}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("bulk: writing tar header for %s: %w", name, err)
+		}
+		if _, err := tw.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("bulk: writing tar body for %s: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("bulk: closing tar writer: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("bulk: closing gzip writer: %w", err)
+	}
+	return t.out.Close()
+}
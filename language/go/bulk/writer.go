@@ -0,0 +1,147 @@
+package bulk
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithGzip gzip-compresses each NDJSON stream individually. It only
+// takes effect for a directory-backed Writer: a tar.gz-backed Writer is
+// already compressed as a whole archive, so per-file gzip would just
+// waste cycles re-compressing already-compressed bytes.
+func WithGzip() Option {
+	return func(w *Writer) { w.gzip = true }
+}
+
+// writerStream is one open "<Type>.ndjson[.gz]" output.
+type writerStream struct {
+	fileName string
+	raw      io.WriteCloser
+	gz       *gzip.Writer
+	enc      *json.Encoder
+	count    int
+}
+
+func (s *writerStream) close() error {
+	if s.gz != nil {
+		if err := s.gz.Close(); err != nil {
+			return err
+		}
+	}
+	return s.raw.Close()
+}
+
+// Writer dispatches wellally resources by concrete type into the
+// matching NDJSON stream of a target (directory or tar.gz archive).
+type Writer struct {
+	tgt     target
+	gzip    bool
+	streams map[string]*writerStream
+}
+
+// NewWriterDir returns a Writer that writes one "<Type>.ndjson[.gz]"
+// file per resource type under dir, creating dir if needed.
+func NewWriterDir(dir string, opts ...Option) (*Writer, error) {
+	tgt, err := newDirTarget(dir)
+	if err != nil {
+		return nil, err
+	}
+	return newWriter(tgt, opts...), nil
+}
+
+// NewWriterTarGz returns a Writer that collects every resource-type
+// stream and writes them as a single tar.gz archive at path on Close.
+func NewWriterTarGz(path string, opts ...Option) (*Writer, error) {
+	tgt, err := newTarGzTarget(path)
+	if err != nil {
+		return nil, err
+	}
+	w := newWriter(tgt, opts...)
+	w.gzip = false // the archive is compressed as a whole; see WithGzip
+	return w, nil
+}
+
+func newWriter(tgt target, opts ...Option) *Writer {
+	w := &Writer{tgt: tgt, streams: make(map[string]*writerStream)}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write encodes resource as one NDJSON line in the stream for its
+// concrete type, opening that stream on first use.
+func (w *Writer) Write(resource any) error {
+	typeName, err := resourceTypeFor(resource)
+	if err != nil {
+		return err
+	}
+	stream, err := w.streamFor(typeName)
+	if err != nil {
+		return err
+	}
+	if err := stream.enc.Encode(resource); err != nil {
+		return fmt.Errorf("bulk: encoding %s: %w", typeName, err)
+	}
+	stream.count++
+	return nil
+}
+
+func (w *Writer) streamFor(typeName string) (*writerStream, error) {
+	if stream, ok := w.streams[typeName]; ok {
+		return stream, nil
+	}
+	fileName := typeName + ".ndjson"
+	if w.gzip {
+		fileName += ".gz"
+	}
+	raw, err := w.tgt.create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	stream := &writerStream{fileName: fileName, raw: raw}
+	out := io.Writer(raw)
+	if w.gzip {
+		stream.gz = gzip.NewWriter(raw)
+		out = stream.gz
+	}
+	stream.enc = json.NewEncoder(out)
+	w.streams[typeName] = stream
+	return stream, nil
+}
+
+// Manifest returns the FHIR Bulk Data style output[] entries for every
+// stream that has been written to, in resource-type order. urlPrefix is
+// prepended to each file name to build its URL, e.g. a directory path
+// or a base URL the archive is published under.
+func (w *Writer) Manifest(urlPrefix string) []ManifestEntry {
+	var entries []ManifestEntry
+	for _, typeName := range resourceTypes {
+		stream, ok := w.streams[typeName]
+		if !ok {
+			continue
+		}
+		entries = append(entries, ManifestEntry{
+			Type:  typeName,
+			URL:   urlPrefix + "/" + stream.fileName,
+			Count: stream.count,
+		})
+	}
+	return entries
+}
+
+// Close flushes and closes every open stream, then finalizes the
+// target (e.g. writing the tar.gz trailer).
+func (w *Writer) Close() error {
+	for _, stream := range w.streams {
+		if err := stream.close(); err != nil {
+			return fmt.Errorf("bulk: closing %s: %w", stream.fileName, err)
+		}
+	}
+	return w.tgt.Close()
+}
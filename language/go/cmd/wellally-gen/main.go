@@ -0,0 +1,258 @@
+// Command wellally-gen regenerates the schema package's Go structs from
+// the JSON Schemas embedded in the validate package, so that the
+// wellally and schema representations never drift apart.
+//
+// It resolves inline nested "object" properties and same-document
+// "#/definitions/..." references into their own generated struct types;
+// it does not follow $ref across schema files. The embedded schemas
+// themselves are also intentionally partial (e.g. health.schema.json
+// declares far fewer properties than wellally.Person has), so the
+// structs this produces only reproduce the fields each schema document
+// actually declares, not the full hand-written schema package — running
+// it keeps schema/models_generated.go honest with validate/schemas, not
+// a promise that every hand-written field in schema/models.go has a
+// schema-backed counterpart.
+//
+// Usage:
+//
+//	wellally-gen -schemas ../../validate/schemas -out ../../schema/models_generated.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type schemaDoc struct {
+	ID          string                `json:"$id"`
+	Title       string                `json:"title"`
+	Type        string                `json:"type"`
+	Required    []string              `json:"required"`
+	Properties  map[string]schemaProp `json:"properties"`
+	Definitions map[string]schemaProp `json:"definitions"`
+}
+
+type schemaProp struct {
+	Type       string                `json:"type"`
+	Ref        string                `json:"$ref"`
+	Items      *schemaProp           `json:"items"`
+	Properties map[string]schemaProp `json:"properties"`
+	Required   []string              `json:"required"`
+}
+
+func main() {
+	schemasDir := flag.String("schemas", "schemas", "directory of *.schema.json files to generate from")
+	outPath := flag.String("out", "models_generated.go", "output Go file path")
+	flag.Parse()
+
+	docs, err := loadSchemas(*schemasDir)
+	if err != nil {
+		log.Fatalf("wellally-gen: %v", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by wellally-gen from the JSON Schemas under validate/schemas. DO NOT EDIT.\n\n")
+	b.WriteString("package schema\n\n")
+	for _, doc := range docs {
+		writeStruct(&b, doc)
+	}
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		log.Fatalf("wellally-gen: formatting generated source: %v", err)
+	}
+
+	if err := os.WriteFile(*outPath, formatted, 0o644); err != nil {
+		log.Fatalf("wellally-gen: writing %s: %v", *outPath, err)
+	}
+}
+
+func loadSchemas(dir string) ([]schemaDoc, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	var docs []schemaDoc
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".schema.json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
+		}
+		var doc schemaDoc
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", entry.Name(), err)
+		}
+		if doc.Title == "" || doc.Type != "object" || len(doc.Properties) == 0 {
+			// Shared definition-only documents (e.g. common.schema.json)
+			// have no top-level struct of their own.
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Title < docs[j].Title })
+	return docs, nil
+}
+
+// generator resolves one schemaDoc's properties into Go types, emitting a
+// nested struct (deduplicated by name) for every inline "object" property
+// and every "#/definitions/..." reference it encounters along the way.
+type generator struct {
+	definitions map[string]schemaProp
+	generated   map[string]bool
+	structs     []string
+}
+
+func newGenerator(doc schemaDoc) *generator {
+	return &generator{definitions: doc.Definitions, generated: make(map[string]bool)}
+}
+
+func writeStruct(b *strings.Builder, doc schemaDoc) {
+	g := newGenerator(doc)
+	required := make(map[string]bool, len(doc.Required))
+	for _, name := range doc.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(doc.Properties))
+	for name := range doc.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(b, "// %s is generated from %s.\n", doc.Title, doc.ID)
+	fmt.Fprintf(b, "type %s struct {\n", doc.Title)
+	for _, name := range names {
+		prop := doc.Properties[name]
+		fieldName := exportedName(name)
+		goType := g.goType(doc.Title, fieldName, prop)
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", fieldName, goType, tag)
+	}
+	b.WriteString("}\n\n")
+	for _, s := range g.structs {
+		b.WriteString(s)
+	}
+}
+
+// goType returns the Go type for prop. parentName and fieldName name the
+// struct a freshly generated nested type would belong to, used only when
+// prop needs one (an inline object, or an unseen $ref).
+func (g *generator) goType(parentName, fieldName string, prop schemaProp) string {
+	if prop.Ref != "" {
+		name, ok := refName(prop.Ref)
+		if !ok {
+			return "any" // a ref outside this document; not resolvable here
+		}
+		def, ok := g.definitions[name]
+		if !ok {
+			return "any"
+		}
+		g.ensureStruct(name, def)
+		return name
+	}
+	switch prop.Type {
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "object":
+		if len(prop.Properties) == 0 {
+			return "map[string]any" // the schema doesn't describe this object's shape
+		}
+		name := parentName + fieldName
+		g.ensureStruct(name, prop)
+		return name
+	case "array":
+		if prop.Items != nil {
+			return "[]" + g.goType(parentName, singularize(fieldName), *prop.Items)
+		}
+		return "[]any"
+	default:
+		return "any"
+	}
+}
+
+// ensureStruct emits a struct named name generated from prop.Properties,
+// unless one by that name has already been emitted.
+func (g *generator) ensureStruct(name string, prop schemaProp) {
+	if g.generated[name] {
+		return
+	}
+	g.generated[name] = true
+
+	required := make(map[string]bool, len(prop.Required))
+	for _, n := range prop.Required {
+		required[n] = true
+	}
+	names := make([]string, 0, len(prop.Properties))
+	for n := range prop.Properties {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, n := range names {
+		child := prop.Properties[n]
+		fieldName := exportedName(n)
+		goType := g.goType(name, fieldName, child)
+		tag := n
+		if !required[n] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", fieldName, goType, tag)
+	}
+	b.WriteString("}\n\n")
+	g.structs = append(g.structs, b.String())
+}
+
+// refName extracts the definition name from a same-document JSON pointer
+// like "#/definitions/Coding". It reports false for any other ref shape
+// (e.g. a pointer into a different schema file), which this generator
+// doesn't resolve.
+func refName(ref string) (string, bool) {
+	const prefix = "#/definitions/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, prefix), true
+}
+
+// singularize turns a plural field name like "Results" into a name
+// suitable for its array item's struct ("Result"), falling back to
+// appending "Item" when name doesn't look plural.
+func singularize(name string) string {
+	if strings.HasSuffix(name, "s") && len(name) > 1 {
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
+
+func exportedName(jsonName string) string {
+	parts := strings.Split(jsonName, "_")
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	name := strings.Join(parts, "")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,109 @@
+package dicomsr
+
+import "fmt"
+
+// Tag identifies a DICOM data element by its (group, element) pair.
+type Tag struct {
+	Group   uint16
+	Element uint16
+}
+
+func (t Tag) String() string {
+	return fmt.Sprintf("(%04X,%04X)", t.Group, t.Element)
+}
+
+// Well-known tags this package reads or writes.
+var (
+	TagStudyInstanceUID         = Tag{0x0020, 0x000D}
+	TagModality                 = Tag{0x0008, 0x0060}
+	TagCTDIvol                  = Tag{0x0018, 0x9345}
+	TagDLP                      = Tag{0x0018, 0x9346}
+	TagReferencedSOPSequence    = Tag{0x0008, 0x1199}
+	TagReferencedSOPInstanceUID = Tag{0x0008, 0x1155}
+	TagContentSequence          = Tag{0x0040, 0xA730}
+	TagConceptNameCodeSequence  = Tag{0x0040, 0xA043}
+	TagCodeValue                = Tag{0x0008, 0x0100}
+	TagCodeMeaning              = Tag{0x0008, 0x0104}
+	TagTextValue                = Tag{0x0040, 0xA160}
+	TagValueType                = Tag{0x0040, 0xA040}
+)
+
+// DCM concept name codes used by TID 2000 section headers.
+const (
+	ConceptFindings   = "121070"
+	ConceptImpression = "121072"
+)
+
+// VR is a DICOM value representation, e.g. "UI", "CS", "DS", "SQ".
+type VR string
+
+// Element is a single DICOM data element. Value holds a string for
+// character-based VRs, a float64 for DS/IS/FL/FD (a DS/IS value that does
+// not parse as a number is kept as a string), or []*Dataset for SQ.
+type Element struct {
+	Tag   Tag
+	VR    VR
+	Value any
+}
+
+// Dataset is an ordered set of DICOM data elements, such as the top
+// level of an SR object or one item of a sequence.
+type Dataset struct {
+	Elements []*Element
+	byTag    map[Tag]*Element
+}
+
+// Add appends e to the dataset and indexes it for lookup.
+func (d *Dataset) Add(e *Element) {
+	d.Elements = append(d.Elements, e)
+	if d.byTag == nil {
+		d.byTag = make(map[Tag]*Element)
+	}
+	d.byTag[e.Tag] = e
+}
+
+func (d *Dataset) get(tag Tag) *Element {
+	if d == nil {
+		return nil
+	}
+	if d.byTag == nil {
+		for _, e := range d.Elements {
+			if e.Tag == tag {
+				return e
+			}
+		}
+		return nil
+	}
+	return d.byTag[tag]
+}
+
+// String returns the string value stored at tag, or "" if absent or not
+// a string-valued element.
+func (d *Dataset) String(tag Tag) string {
+	e := d.get(tag)
+	if e == nil {
+		return ""
+	}
+	s, _ := e.Value.(string)
+	return s
+}
+
+// Float returns the numeric value stored at tag.
+func (d *Dataset) Float(tag Tag) (float64, bool) {
+	e := d.get(tag)
+	if e == nil {
+		return 0, false
+	}
+	f, ok := e.Value.(float64)
+	return f, ok
+}
+
+// Sequence returns the item datasets stored at tag.
+func (d *Dataset) Sequence(tag Tag) []*Dataset {
+	e := d.get(tag)
+	if e == nil {
+		return nil
+	}
+	items, _ := e.Value.([]*Dataset)
+	return items
+}
@@ -0,0 +1,21 @@
+// Package dicomsr parses DICOM Structured Report (SR) objects into
+// wellally.ImagingReport, and renders the reverse. CDA (XML) radiology
+// reports are not parsed.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+// Spec: DICOM PS3.3 (Structured Reporting) TID 2000 "Basic Diagnostic
+// Imaging Report"
+//
+// ParseSR reads an Explicit VR Little Endian DICOM dataset (with or
+// without the 128-byte Part 10 preamble) and maps (0020,000D)
+// StudyInstanceUID, (0008,0060) Modality, (0018,9345) CTDIvol and
+// (0018,9346) DLP, referenced instance UIDs, and the TID 2000
+// Findings/Impression sections of the SR content tree onto the matching
+// ImagingReport fields. RenderSR produces the reverse: a minimal SR
+// dataset an imaging system can round-trip.
+//
+// This package implements just enough of DICOM's Explicit VR Little
+// Endian transfer syntax and SR content-tree structure to support that
+// mapping; it is not a general-purpose DICOM toolkit.
+package dicomsr
@@ -0,0 +1,200 @@
+package dicomsr
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const undefinedLength = 0xFFFFFFFF
+
+var longFormVR = map[VR]bool{
+	"OB": true, "OW": true, "OF": true, "SQ": true, "UT": true, "UN": true, "OD": true, "OL": true,
+}
+
+// ReadDataset parses an Explicit VR Little Endian DICOM dataset from r.
+// If the Part 10 preamble ("DICM" at byte offset 128) is present, the
+// 128-byte preamble and "DICM" prefix are skipped first; the File Meta
+// Information group (0002,xxxx) that follows is read into the returned
+// dataset like any other element.
+func ReadDataset(r io.Reader) (*Dataset, error) {
+	br := bufio.NewReader(r)
+	if err := skipPreamble(br); err != nil {
+		return nil, err
+	}
+	return readElements(br, undefinedLength)
+}
+
+func skipPreamble(br *bufio.Reader) error {
+	peek, err := br.Peek(132)
+	if err != nil {
+		// Shorter than a preamble: treat as a bare dataset.
+		return nil
+	}
+	if string(peek[128:132]) != "DICM" {
+		return nil
+	}
+	if _, err := br.Discard(132); err != nil {
+		return fmt.Errorf("dicomsr: discarding preamble: %w", err)
+	}
+	return nil
+}
+
+// readElements reads explicit-VR data elements until limit bytes have
+// been consumed (when limit != undefinedLength) or EOF / an item/sequence
+// delimiter is hit.
+func readElements(br *bufio.Reader, limit uint32) (*Dataset, error) {
+	ds := &Dataset{}
+	var consumed uint32
+	for limit == undefinedLength || consumed < limit {
+		tagBytes := make([]byte, 4)
+		n, err := io.ReadFull(br, tagBytes)
+		if err == io.EOF || n == 0 {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("dicomsr: reading tag: %w", err)
+		}
+		consumed += 4
+		tag := Tag{
+			Group:   binary.LittleEndian.Uint16(tagBytes[0:2]),
+			Element: binary.LittleEndian.Uint16(tagBytes[2:4]),
+		}
+
+		if tag == (Tag{0xFFFE, 0xE00D}) || tag == (Tag{0xFFFE, 0xE0DD}) {
+			// Item/sequence delimitation: consume its 4-byte zero length and stop.
+			var skip [4]byte
+			io.ReadFull(br, skip[:])
+			consumed += 4
+			break
+		}
+
+		vrBytes := make([]byte, 2)
+		if _, err := io.ReadFull(br, vrBytes); err != nil {
+			return nil, fmt.Errorf("dicomsr: reading VR for %s: %w", tag, err)
+		}
+		consumed += 2
+		vr := VR(vrBytes)
+
+		var length uint32
+		if longFormVR[vr] {
+			var reserved [2]byte
+			io.ReadFull(br, reserved[:])
+			var lenBytes [4]byte
+			if _, err := io.ReadFull(br, lenBytes[:]); err != nil {
+				return nil, fmt.Errorf("dicomsr: reading length for %s: %w", tag, err)
+			}
+			length = binary.LittleEndian.Uint32(lenBytes[:])
+			consumed += 6
+		} else {
+			var lenBytes [2]byte
+			if _, err := io.ReadFull(br, lenBytes[:]); err != nil {
+				return nil, fmt.Errorf("dicomsr: reading length for %s: %w", tag, err)
+			}
+			length = uint32(binary.LittleEndian.Uint16(lenBytes[:]))
+			consumed += 2
+		}
+
+		elem, read, err := readValue(br, tag, vr, length)
+		if err != nil {
+			return nil, err
+		}
+		consumed += read
+		ds.Add(elem)
+	}
+	return ds, nil
+}
+
+func readValue(br *bufio.Reader, tag Tag, vr VR, length uint32) (*Element, uint32, error) {
+	if vr == "SQ" {
+		items, read, err := readSequence(br, length)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &Element{Tag: tag, VR: vr, Value: items}, read, nil
+	}
+
+	if length == undefinedLength {
+		return nil, 0, fmt.Errorf("dicomsr: %s: undefined length only supported for SQ", tag)
+	}
+
+	raw := make([]byte, length)
+	if length > 0 {
+		if _, err := io.ReadFull(br, raw); err != nil {
+			return nil, 0, fmt.Errorf("dicomsr: reading value for %s: %w", tag, err)
+		}
+	}
+
+	switch vr {
+	case "DS", "IS":
+		s := strings.TrimSpace(string(raw))
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return &Element{Tag: tag, VR: vr, Value: f}, length, nil
+		}
+		return &Element{Tag: tag, VR: vr, Value: s}, length, nil
+	case "FL":
+		if length >= 4 {
+			bits := binary.LittleEndian.Uint32(raw)
+			return &Element{Tag: tag, VR: vr, Value: float64(math.Float32frombits(bits))}, length, nil
+		}
+		return &Element{Tag: tag, VR: vr}, length, nil
+	case "FD":
+		if length >= 8 {
+			bits := binary.LittleEndian.Uint64(raw)
+			return &Element{Tag: tag, VR: vr, Value: math.Float64frombits(bits)}, length, nil
+		}
+		return &Element{Tag: tag, VR: vr}, length, nil
+	default:
+		s := strings.TrimRight(string(raw), " \x00")
+		return &Element{Tag: tag, VR: vr, Value: s}, length, nil
+	}
+}
+
+func readSequence(br *bufio.Reader, length uint32) ([]*Dataset, uint32, error) {
+	var items []*Dataset
+	var consumed uint32
+	for length == undefinedLength || consumed < length {
+		tagBytes := make([]byte, 4)
+		n, err := io.ReadFull(br, tagBytes)
+		if err == io.EOF || n == 0 {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("dicomsr: reading sequence item tag: %w", err)
+		}
+		consumed += 4
+		tag := Tag{
+			Group:   binary.LittleEndian.Uint16(tagBytes[0:2]),
+			Element: binary.LittleEndian.Uint16(tagBytes[2:4]),
+		}
+		if tag == (Tag{0xFFFE, 0xE0DD}) {
+			var skip [4]byte
+			io.ReadFull(br, skip[:])
+			consumed += 4
+			break
+		}
+		if tag != (Tag{0xFFFE, 0xE000}) {
+			return nil, 0, fmt.Errorf("dicomsr: expected Item tag, got %s", tag)
+		}
+		var lenBytes [4]byte
+		if _, err := io.ReadFull(br, lenBytes[:]); err != nil {
+			return nil, 0, fmt.Errorf("dicomsr: reading item length: %w", err)
+		}
+		itemLength := binary.LittleEndian.Uint32(lenBytes[:])
+		consumed += 4
+
+		item, err := readElements(br, itemLength)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+		if itemLength != undefinedLength {
+			consumed += itemLength
+		}
+	}
+	return items, consumed, nil
+}
@@ -0,0 +1,204 @@
+package dicomsr
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+var (
+	tagContentDate = Tag{0x0008, 0x0023}
+	tagContentTime = Tag{0x0008, 0x0033}
+)
+
+const dicomModalitySystem = "http://dicom.nema.org/resources/ontology/DCM"
+
+// ParseSR reads a DICOM SR object (TID 2000 "Basic Diagnostic Imaging
+// Report") from r and maps it onto a wellally.ImagingReport.
+// ID, PatientID, and BodySite have no DICOM tag this package maps yet and
+// are left zero-valued; callers typically fill those in from the order
+// that triggered the study.
+func ParseSR(r io.Reader) (*wellally.ImagingReport, error) {
+	ds, err := ReadDataset(r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &wellally.ImagingReport{
+		Modality: wellally.Modality{
+			System: dicomModalitySystem,
+			Code:   wellally.ModalityCode(ds.String(TagModality)),
+		},
+	}
+
+	if studyUID := ds.String(TagStudyInstanceUID); studyUID != "" {
+		report.StudyInstanceUID = &studyUID
+	}
+
+	if reportedAt, ok := parseContentDateTime(ds); ok {
+		report.ReportedAt = reportedAt
+	}
+
+	if ctdi, ok := ds.Float(TagCTDIvol); ok {
+		report.RadiationDose = ensureDose(report.RadiationDose)
+		report.RadiationDose.CTDIVolMGy = &ctdi
+	}
+	if dlp, ok := ds.Float(TagDLP); ok {
+		report.RadiationDose = ensureDose(report.RadiationDose)
+		report.RadiationDose.DLPMGyCm = &dlp
+	}
+
+	for _, ref := range ds.Sequence(TagReferencedSOPSequence) {
+		uid := ref.String(TagReferencedSOPInstanceUID)
+		if uid == "" {
+			continue
+		}
+		url := "dicomweb://" + uid
+		report.Attachments = append(report.Attachments, wellally.Attachment{
+			URL:  &url,
+			Type: strPtr("application/dicom"),
+		})
+	}
+
+	report.Findings = findSectionTexts(ds, ConceptFindings)
+	if impression := findSectionTexts(ds, ConceptImpression); len(impression) > 0 {
+		joined := strings.Join(impression, " ")
+		report.Impression = &joined
+	}
+
+	return report, nil
+}
+
+// RenderSR builds a minimal DICOM SR dataset (TID 2000 shaped) carrying
+// report's StudyInstanceUID, Modality, RadiationDose, Attachments, and
+// Findings/Impression, and serializes it as Explicit VR Little Endian
+// (no Part 10 preamble).
+func RenderSR(report *wellally.ImagingReport) ([]byte, error) {
+	ds := &Dataset{}
+	ds.Add(&Element{Tag: TagModality, VR: "CS", Value: string(report.Modality.Code)})
+	if report.StudyInstanceUID != nil {
+		ds.Add(&Element{Tag: TagStudyInstanceUID, VR: "UI", Value: *report.StudyInstanceUID})
+	}
+	if !report.ReportedAt.IsZero() {
+		ds.Add(&Element{Tag: tagContentDate, VR: "DA", Value: report.ReportedAt.Format("20060102")})
+		ds.Add(&Element{Tag: tagContentTime, VR: "TM", Value: report.ReportedAt.Format("150405")})
+	}
+	if report.RadiationDose != nil {
+		if report.RadiationDose.CTDIVolMGy != nil {
+			ds.Add(&Element{Tag: TagCTDIvol, VR: "DS", Value: *report.RadiationDose.CTDIVolMGy})
+		}
+		if report.RadiationDose.DLPMGyCm != nil {
+			ds.Add(&Element{Tag: TagDLP, VR: "DS", Value: *report.RadiationDose.DLPMGyCm})
+		}
+	}
+
+	if len(report.Attachments) > 0 {
+		var refs []*Dataset
+		for _, a := range report.Attachments {
+			if a.URL == nil {
+				continue
+			}
+			ref := &Dataset{}
+			ref.Add(&Element{Tag: TagReferencedSOPInstanceUID, VR: "UI", Value: strings.TrimPrefix(*a.URL, "dicomweb://")})
+			refs = append(refs, ref)
+		}
+		ds.Add(&Element{Tag: TagReferencedSOPSequence, VR: "SQ", Value: refs})
+	}
+
+	var sections []*Dataset
+	if len(report.Findings) > 0 {
+		sections = append(sections, textSection(ConceptFindings, "Findings", report.Findings))
+	}
+	if report.Impression != nil && *report.Impression != "" {
+		sections = append(sections, textSection(ConceptImpression, "Impression", []string{*report.Impression}))
+	}
+	if len(sections) > 0 {
+		ds.Add(&Element{Tag: TagContentSequence, VR: "SQ", Value: sections})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDataset(&buf, ds); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func textSection(conceptCode, conceptMeaning string, texts []string) *Dataset {
+	section := &Dataset{}
+	section.Add(&Element{Tag: TagValueType, VR: "CS", Value: "CONTAINER"})
+	concept := &Dataset{}
+	concept.Add(&Element{Tag: TagCodeValue, VR: "SH", Value: conceptCode})
+	concept.Add(&Element{Tag: TagCodeMeaning, VR: "LO", Value: conceptMeaning})
+	section.Add(&Element{Tag: TagConceptNameCodeSequence, VR: "SQ", Value: []*Dataset{concept}})
+
+	var children []*Dataset
+	for _, text := range texts {
+		child := &Dataset{}
+		child.Add(&Element{Tag: TagValueType, VR: "CS", Value: "TEXT"})
+		child.Add(&Element{Tag: TagTextValue, VR: "UT", Value: text})
+		children = append(children, child)
+	}
+	section.Add(&Element{Tag: TagContentSequence, VR: "SQ", Value: children})
+	return section
+}
+
+func findSectionTexts(ds *Dataset, code string) []string {
+	var texts []string
+	for _, item := range ds.Sequence(TagContentSequence) {
+		if conceptCode(item) == code {
+			texts = append(texts, collectAllText(item)...)
+		} else {
+			texts = append(texts, findSectionTexts(item, code)...)
+		}
+	}
+	return texts
+}
+
+func conceptCode(item *Dataset) string {
+	seq := item.Sequence(TagConceptNameCodeSequence)
+	if len(seq) == 0 {
+		return ""
+	}
+	return seq[0].String(TagCodeValue)
+}
+
+func collectAllText(item *Dataset) []string {
+	var texts []string
+	if tv := item.String(TagTextValue); tv != "" {
+		texts = append(texts, tv)
+	}
+	for _, child := range item.Sequence(TagContentSequence) {
+		texts = append(texts, collectAllText(child)...)
+	}
+	return texts
+}
+
+func parseContentDateTime(ds *Dataset) (time.Time, bool) {
+	date := ds.String(tagContentDate)
+	if date == "" {
+		return time.Time{}, false
+	}
+	layout := "20060102"
+	value := date
+	if t := ds.String(tagContentTime); t != "" {
+		layout = "20060102150405"
+		value = date + t
+	}
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+func ensureDose(dose *wellally.RadiationDose) *wellally.RadiationDose {
+	if dose == nil {
+		return &wellally.RadiationDose{}
+	}
+	return dose
+}
+
+func strPtr(s string) *string { return &s }
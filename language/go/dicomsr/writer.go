@@ -0,0 +1,108 @@
+package dicomsr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDataset serializes ds as an Explicit VR Little Endian DICOM
+// dataset (no Part 10 preamble).
+func WriteDataset(w io.Writer, ds *Dataset) error {
+	for _, e := range ds.Elements {
+		if err := writeElement(w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeElement(w io.Writer, e *Element) error {
+	if err := writeTag(w, e.Tag); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(e.VR)); err != nil {
+		return fmt.Errorf("dicomsr: writing VR for %s: %w", e.Tag, err)
+	}
+
+	if e.VR == "SQ" {
+		items, _ := e.Value.([]*Dataset)
+		return writeSequenceValue(w, items)
+	}
+
+	value := encodeScalar(e.VR, e.Value)
+	if longFormVR[e.VR] {
+		var reserved [2]byte
+		w.Write(reserved[:])
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(value))); err != nil {
+			return fmt.Errorf("dicomsr: writing length for %s: %w", e.Tag, err)
+		}
+	} else {
+		if err := binary.Write(w, binary.LittleEndian, uint16(len(value))); err != nil {
+			return fmt.Errorf("dicomsr: writing length for %s: %w", e.Tag, err)
+		}
+	}
+	_, err := w.Write(value)
+	return err
+}
+
+func writeTag(w io.Writer, tag Tag) error {
+	var buf [4]byte
+	binary.LittleEndian.PutUint16(buf[0:2], tag.Group)
+	binary.LittleEndian.PutUint16(buf[2:4], tag.Element)
+	_, err := w.Write(buf[:])
+	return err
+}
+
+func writeSequenceValue(w io.Writer, items []*Dataset) error {
+	var reserved [2]byte
+	w.Write(reserved[:])
+	if err := binary.Write(w, binary.LittleEndian, uint32(undefinedLength)); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := writeTag(w, Tag{0xFFFE, 0xE000}); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, uint32(undefinedLength)); err != nil {
+			return err
+		}
+		if err := WriteDataset(w, item); err != nil {
+			return err
+		}
+		if err := writeTag(w, Tag{0xFFFE, 0xE00D}); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, uint32(0)); err != nil {
+			return err
+		}
+	}
+	return writeSequenceDelimiter(w)
+}
+
+func writeSequenceDelimiter(w io.Writer) error {
+	if err := writeTag(w, Tag{0xFFFE, 0xE0DD}); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, uint32(0))
+}
+
+func encodeScalar(vr VR, value any) []byte {
+	switch vr {
+	case "DS", "IS":
+		if f, ok := value.(float64); ok {
+			return []byte(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		if s, ok := value.(string); ok {
+			return []byte(s)
+		}
+		return nil
+	default:
+		s, _ := value.(string)
+		if len(s)%2 != 0 {
+			s += " "
+		}
+		return []byte(s)
+	}
+}
@@ -0,0 +1,127 @@
+package fhir
+
+import (
+	"fmt"
+	"time"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+const (
+	fhirDateLayout     = "2006-01-02"
+	fhirDateTimeLayout = time.RFC3339
+)
+
+func parseFHIRDate(s string) (time.Time, error) {
+	if t, err := time.Parse(fhirDateLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(fhirDateTimeLayout, s)
+}
+
+// ToFHIR converts a wellally domain value into its FHIR R4 representation.
+// It supports wellally.Person, wellally.LabReport, wellally.ImagingReport,
+// wellally.MedicationRecord, and wellally.FamilyHealthTree; anything else
+// returns an *UnsupportedTypeError.
+//
+// LabReport, ImagingReport, and FamilyHealthTree don't fit a single FHIR
+// resource (a lab report is a DiagnosticReport plus one Observation per
+// result, an imaging report is an ImagingStudy plus a companion
+// DiagnosticReport carrying its narrative, and a family tree is one
+// FamilyMemberHistory per member), so all three are returned as a *Bundle;
+// FromFHIR accepts that Bundle back to reverse the conversion. The other
+// two map onto exactly one resource each.
+func ToFHIR(v any) (Resource, error) {
+	switch val := v.(type) {
+	case wellally.Person:
+		return PersonToPatient(val), nil
+	case wellally.LabReport:
+		dr, observations := LabReportToFHIR(val)
+		resources := make([]Resource, 0, len(observations)+1)
+		resources = append(resources, dr)
+		for _, obs := range observations {
+			resources = append(resources, obs)
+		}
+		return NewBundle(resources...), nil
+	case wellally.ImagingReport:
+		study := ImagingReportToFHIR(val)
+		report := ImagingReportToFHIRReport(val, study)
+		return NewBundle(study, report), nil
+	case wellally.MedicationRecord:
+		return MedicationRecordToMedicationStatement(val), nil
+	case wellally.FamilyHealthTree:
+		histories := FamilyHealthTreeToFHIR(val)
+		resources := make([]Resource, 0, len(histories))
+		for _, history := range histories {
+			resources = append(resources, history)
+		}
+		return NewBundle(resources...), nil
+	default:
+		return nil, &UnsupportedTypeError{Value: v}
+	}
+}
+
+// FromFHIR converts a FHIR R4 resource back into its wellally domain value.
+// A *Bundle produced by ToFHIR for a LabReport, ImagingReport, or
+// FamilyHealthTree is unwrapped and dispatched based on the resource types its entries carry.
+func FromFHIR(res Resource) (any, error) {
+	switch r := res.(type) {
+	case *Patient:
+		return PatientToPerson(r)
+	case *DiagnosticReport:
+		return FHIRToLabReport(r, nil)
+	case *ImagingStudy:
+		return ImagingStudyToImagingReport(r)
+	case *MedicationStatement:
+		return MedicationStatementToMedicationRecord(r)
+	case *FamilyMemberHistory:
+		return FHIRToFamilyHealthTree(r.Patient.ID(), []*FamilyMemberHistory{r}), nil
+	case *Bundle:
+		return fromFHIRBundle(r)
+	default:
+		return nil, &UnsupportedTypeError{Value: res}
+	}
+}
+
+// fromFHIRBundle reverses ToFHIR's LabReport/ImagingReport/FamilyHealthTree
+// bundling by sorting a Bundle's entries back into their concrete resource
+// types.
+func fromFHIRBundle(b *Bundle) (any, error) {
+	var dr *DiagnosticReport
+	var observations []*Observation
+	var histories []*FamilyMemberHistory
+	var study *ImagingStudy
+	var imagingReport *ImagingReportDiagnosticReport
+	for _, entry := range b.Entry {
+		switch res := entry.Resource.(type) {
+		case *DiagnosticReport:
+			dr = res
+		case *Observation:
+			observations = append(observations, res)
+		case *FamilyMemberHistory:
+			histories = append(histories, res)
+		case *ImagingStudy:
+			study = res
+		case *ImagingReportDiagnosticReport:
+			imagingReport = res
+		}
+	}
+
+	switch {
+	case study != nil:
+		report, err := ImagingStudyToImagingReport(study)
+		if err != nil {
+			return nil, err
+		}
+		if imagingReport != nil {
+			MergeImagingReportNarrative(&report, imagingReport)
+		}
+		return report, nil
+	case dr != nil:
+		return FHIRToLabReport(dr, observations)
+	case len(histories) > 0:
+		return FHIRToFamilyHealthTree(histories[0].Patient.ID(), histories), nil
+	default:
+		return nil, fmt.Errorf("fhir: bundle has no DiagnosticReport, ImagingStudy, or FamilyMemberHistory entries to convert")
+	}
+}
@@ -0,0 +1,129 @@
+package fhir
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// DiagnosticReport is the FHIR R4 DiagnosticReport resource produced from a
+// wellally.LabReport. The individual LabResult entries are carried as
+// separate Observation resources referenced from Result.
+type DiagnosticReport struct {
+	ResourceType ResourceType             `json:"resourceType"`
+	ID           string                   `json:"id"`
+	Status       string                   `json:"status"`
+	Code         wellally.CodeableConcept `json:"code,omitempty"`
+	Subject      *Reference               `json:"subject,omitempty"`
+	Issued       string                   `json:"issued,omitempty"`
+	Performer    []Reference              `json:"performer,omitempty"`
+	Result       []Reference              `json:"result,omitempty"`
+}
+
+// FHIRResourceType implements Resource.
+func (d *DiagnosticReport) FHIRResourceType() ResourceType { return ResourceTypeDiagnosticReport }
+
+// Observation is the FHIR R4 Observation resource representing a single
+// wellally.LabResult.
+type Observation struct {
+	ResourceType   ResourceType               `json:"resourceType"`
+	ID             string                     `json:"id"`
+	Status         string                     `json:"status"`
+	Code           wellally.CodeableConcept   `json:"code"`
+	Subject        *Reference                 `json:"subject,omitempty"`
+	ValueQuantity  *wellally.Quantity         `json:"valueQuantity,omitempty"`
+	ValueCodeable  *wellally.CodeableConcept  `json:"valueCodeableConcept,omitempty"`
+	ValueString    *string                    `json:"valueString,omitempty"`
+	ReferenceRange []wellally.ReferenceRange  `json:"referenceRange,omitempty"`
+	Interpretation []wellally.CodeableConcept `json:"interpretation,omitempty"`
+	Method         *wellally.CodeableConcept  `json:"method,omitempty"`
+}
+
+// FHIRResourceType implements Resource.
+func (o *Observation) FHIRResourceType() ResourceType { return ResourceTypeObservation }
+
+// LabReportToFHIR converts a wellally.LabReport into a DiagnosticReport and
+// one Observation per LabResult, mirroring how FHIR labs are bundled.
+func LabReportToFHIR(report wellally.LabReport) (*DiagnosticReport, []*Observation) {
+	dr := &DiagnosticReport{
+		ResourceType: ResourceTypeDiagnosticReport,
+		ID:           report.ID,
+		Status:       "final",
+		Subject:      NewReference(ResourceTypePatient, report.PatientID),
+		Issued:       report.IssuedAt.Format(fhirDateTimeLayout),
+	}
+	if report.Panel != nil {
+		dr.Code = *report.Panel
+	}
+	if report.Facility != nil && report.Facility.ID != nil {
+		dr.Performer = append(dr.Performer, Reference{Reference: fmt.Sprintf("Organization/%s", *report.Facility.ID)})
+	}
+
+	observations := make([]*Observation, 0, len(report.Results))
+	for i, result := range report.Results {
+		obs := &Observation{
+			ResourceType:   ResourceTypeObservation,
+			ID:             fmt.Sprintf("%s-%d", report.ID, i+1),
+			Status:         "final",
+			Code:           result.Code,
+			Subject:        dr.Subject,
+			ReferenceRange: nil,
+			Method:         result.Method,
+		}
+		if result.ReferenceRange != nil {
+			obs.ReferenceRange = []wellally.ReferenceRange{*result.ReferenceRange}
+		}
+		if result.Interpretation != nil {
+			obs.Interpretation = []wellally.CodeableConcept{{Text: strPtr(string(*result.Interpretation))}}
+		}
+		switch value := result.Value.(type) {
+		case wellally.Quantity:
+			obs.ValueQuantity = &value
+		case wellally.CodeableConcept:
+			obs.ValueCodeable = &value
+		case string:
+			obs.ValueString = &value
+		}
+		observations = append(observations, obs)
+		dr.Result = append(dr.Result, Reference{Reference: fmt.Sprintf("Observation/%s", obs.ID)})
+	}
+	return dr, observations
+}
+
+// FHIRToLabReport reassembles a wellally.LabReport from a DiagnosticReport
+// and the Observation resources it references.
+func FHIRToLabReport(dr *DiagnosticReport, observations []*Observation) (wellally.LabReport, error) {
+	issuedAt, err := parseFHIRDate(dr.Issued)
+	if err != nil {
+		return wellally.LabReport{}, fmt.Errorf("fhir: parsing DiagnosticReport.issued: %w", err)
+	}
+	report := wellally.LabReport{
+		ID:        dr.ID,
+		PatientID: dr.Subject.ID(),
+		IssuedAt:  issuedAt,
+		Panel:     &dr.Code,
+	}
+	for _, obs := range observations {
+		result := wellally.LabResult{
+			Code:   obs.Code,
+			Method: obs.Method,
+		}
+		switch {
+		case obs.ValueQuantity != nil:
+			result.Value = *obs.ValueQuantity
+		case obs.ValueCodeable != nil:
+			result.Value = *obs.ValueCodeable
+		case obs.ValueString != nil:
+			result.Value = *obs.ValueString
+		}
+		if len(obs.ReferenceRange) > 0 {
+			result.ReferenceRange = &obs.ReferenceRange[0]
+		}
+		if len(obs.Interpretation) > 0 && obs.Interpretation[0].Text != nil {
+			interp := wellally.Interpretation(*obs.Interpretation[0].Text)
+			result.Interpretation = &interp
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
@@ -0,0 +1,13 @@
+// Package fhir provides bidirectional mapping between WellAll domain models
+// and HL7 FHIR R4 resources.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+// Spec: https://hl7.org/fhir/R4/
+//
+// Use ToFHIR to convert a wellally type (Person, LabReport, ImagingReport,
+// MedicationRecord, FamilyHealthTree) into its FHIR R4 representation, and
+// FromFHIR to convert a FHIR Resource back into the matching wellally type.
+// Per-type helpers (PersonToPatient, PatientToPerson, and so on) are
+// available when the caller already knows the concrete type on both sides.
+package fhir
@@ -0,0 +1,86 @@
+package fhir
+
+import wellally "github.com/huifer/wellally-schemas/language/go"
+
+// FamilyMemberHistory is the FHIR R4 FamilyMemberHistory resource, one per
+// wellally.FamilyMember.
+type FamilyMemberHistory struct {
+	ResourceType ResourceType                   `json:"resourceType"`
+	ID           string                         `json:"id"`
+	Status       string                         `json:"status"`
+	Patient      *Reference                     `json:"patient,omitempty"`
+	Relationship wellally.CodeableConcept       `json:"relationship"`
+	Sex          *wellally.CodeableConcept      `json:"sex,omitempty"`
+	BornYear     *int                           `json:"bornYear,omitempty"`
+	Deceased     *bool                          `json:"deceasedBoolean,omitempty"`
+	Condition    []FamilyMemberHistoryCondition `json:"condition,omitempty"`
+}
+
+// FamilyMemberHistoryCondition is one FamilyMemberHistory.condition entry.
+type FamilyMemberHistoryCondition struct {
+	Code wellally.CodeableConcept `json:"code"`
+}
+
+// FHIRResourceType implements Resource.
+func (f *FamilyMemberHistory) FHIRResourceType() ResourceType {
+	return ResourceTypeFamilyMemberHistory
+}
+
+// FamilyHealthTreeToFHIR converts every FamilyMember in t (excluding the
+// proband) into a FHIR R4 FamilyMemberHistory, referencing t.ProbandID as
+// the subject patient.
+func FamilyHealthTreeToFHIR(t wellally.FamilyHealthTree) []*FamilyMemberHistory {
+	histories := make([]*FamilyMemberHistory, 0, len(t.Members))
+	for _, member := range t.Members {
+		if member.RelationToProband == wellally.RelationSelf {
+			continue
+		}
+		history := &FamilyMemberHistory{
+			ResourceType: ResourceTypeFamilyMemberHistory,
+			ID:           member.ID,
+			Status:       "completed",
+			Patient:      NewReference(ResourceTypePatient, t.ProbandID),
+			Relationship: wellally.CodeableConcept{Text: strPtr(string(member.RelationToProband))},
+			BornYear:     member.BirthYear,
+			Deceased:     member.Deceased,
+		}
+		if member.Sex != nil {
+			history.Sex = &wellally.CodeableConcept{Text: strPtr(string(*member.Sex))}
+		}
+		for _, cond := range member.Conditions {
+			history.Condition = append(history.Condition, FamilyMemberHistoryCondition{Code: cond})
+		}
+		histories = append(histories, history)
+	}
+	return histories
+}
+
+// FHIRToFamilyHealthTree reassembles a wellally.FamilyHealthTree from the
+// FamilyMemberHistory resources belonging to a single proband.
+func FHIRToFamilyHealthTree(probandID string, histories []*FamilyMemberHistory) wellally.FamilyHealthTree {
+	tree := wellally.FamilyHealthTree{ProbandID: probandID}
+	for _, history := range histories {
+		member := wellally.FamilyMember{
+			ID:                history.ID,
+			RelationToProband: wellally.RelationToProband(safeText(history.Relationship.Text)),
+			BirthYear:         history.BornYear,
+			Deceased:          history.Deceased,
+		}
+		if history.Sex != nil {
+			sex := wellally.Sex(safeText(history.Sex.Text))
+			member.Sex = &sex
+		}
+		for _, cond := range history.Condition {
+			member.Conditions = append(member.Conditions, cond.Code)
+		}
+		tree.Members = append(tree.Members, member)
+	}
+	return tree
+}
+
+func safeText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
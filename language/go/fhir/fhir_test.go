@@ -0,0 +1,154 @@
+package fhir
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// roundTripCase names a pair of fixtures under testdata/: the wellally-side
+// JSON and the FHIR-side JSON it should convert to and from. decodeWellally
+// and decodeFHIR unmarshal each fixture into the concrete type ToFHIR and
+// FromFHIR expect, since both take/return interfaces.
+type roundTripCase struct {
+	name           string
+	decodeWellally func([]byte) (any, error)
+	decodeFHIR     func([]byte) (Resource, error)
+}
+
+var roundTripCases = []roundTripCase{
+	{
+		name: "person",
+		decodeWellally: func(data []byte) (any, error) {
+			var v wellally.Person
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		decodeFHIR: func(data []byte) (Resource, error) {
+			var v Patient
+			err := json.Unmarshal(data, &v)
+			return &v, err
+		},
+	},
+	{
+		name: "labreport",
+		decodeWellally: func(data []byte) (any, error) {
+			var v wellally.LabReport
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		decodeFHIR: func(data []byte) (Resource, error) {
+			var v Bundle
+			err := json.Unmarshal(data, &v)
+			return &v, err
+		},
+	},
+	{
+		name: "imagingreport",
+		decodeWellally: func(data []byte) (any, error) {
+			var v wellally.ImagingReport
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		decodeFHIR: func(data []byte) (Resource, error) {
+			var v Bundle
+			err := json.Unmarshal(data, &v)
+			return &v, err
+		},
+	},
+	{
+		name: "medicationrecord",
+		decodeWellally: func(data []byte) (any, error) {
+			var v wellally.MedicationRecord
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		decodeFHIR: func(data []byte) (Resource, error) {
+			var v MedicationStatement
+			err := json.Unmarshal(data, &v)
+			return &v, err
+		},
+	},
+	{
+		name: "familyhealthtree",
+		decodeWellally: func(data []byte) (any, error) {
+			var v wellally.FamilyHealthTree
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		decodeFHIR: func(data []byte) (Resource, error) {
+			var v Bundle
+			err := json.Unmarshal(data, &v)
+			return &v, err
+		},
+	},
+}
+
+// TestRoundTrip drives ToFHIR and FromFHIR from the fixture on each side and
+// checks the result against the fixture on the other side, so the fixtures
+// double as the expected output for both conversion directions.
+func TestRoundTrip(t *testing.T) {
+	for _, tc := range roundTripCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wellallyFixture := readFixture(t, tc.name+".wellally.json")
+			fhirFixture := readFixture(t, tc.name+".fhir.json")
+
+			wellallyValue, err := tc.decodeWellally(wellallyFixture)
+			if err != nil {
+				t.Fatalf("decoding wellally fixture: %v", err)
+			}
+			fhirGot, err := ToFHIR(wellallyValue)
+			if err != nil {
+				t.Fatalf("ToFHIR: %v", err)
+			}
+			assertJSONEqual(t, "ToFHIR", mustMarshal(t, fhirGot), fhirFixture)
+
+			fhirValue, err := tc.decodeFHIR(fhirFixture)
+			if err != nil {
+				t.Fatalf("decoding fhir fixture: %v", err)
+			}
+			wellallyGot, err := FromFHIR(fhirValue)
+			if err != nil {
+				t.Fatalf("FromFHIR: %v", err)
+			}
+			assertJSONEqual(t, "FromFHIR", mustMarshal(t, wellallyGot), wellallyFixture)
+		})
+	}
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("reading testdata/%s: %v", name, err)
+	}
+	return data
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshaling %T: %v", v, err)
+	}
+	return data
+}
+
+// assertJSONEqual compares got and want structurally (decoded to any) so
+// that key order and insignificant whitespace in the fixtures don't matter.
+func assertJSONEqual(t *testing.T, label string, got, want []byte) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s: unmarshaling got: %v", label, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("%s: unmarshaling want: %v", label, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s mismatch:\n got: %s\nwant: %s", label, got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package fhir
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// dicomStudyInstanceUIDSystem identifies a DICOM Study Instance UID carried
+// as an ImagingStudy.identifier, per the FHIR R4 ImagingStudy definition.
+const dicomStudyInstanceUIDSystem = "urn:dicom:uid"
+
+// ImagingStudy is the FHIR R4 ImagingStudy resource produced from a
+// wellally.ImagingReport. The narrative findings/impression have no direct
+// ImagingStudy element, so they are carried on a companion DiagnosticReport
+// returned alongside it.
+type ImagingStudy struct {
+	ResourceType ResourceType          `json:"resourceType"`
+	ID           string                `json:"id"`
+	Status       string                `json:"status"`
+	Subject      *Reference            `json:"subject,omitempty"`
+	Started      string                `json:"started,omitempty"`
+	Identifier   []wellally.Identifier `json:"identifier,omitempty"`
+	Modality     []wellally.Coding     `json:"modality,omitempty"`
+	Series       []ImagingStudySeries  `json:"series,omitempty"`
+}
+
+// ImagingStudySeries is one ImagingStudy.series entry.
+type ImagingStudySeries struct {
+	BodySite wellally.Coding              `json:"bodySite,omitempty"`
+	Instance []ImagingStudySeriesInstance `json:"instance,omitempty"`
+}
+
+// ImagingStudySeriesInstance references an attached instance, e.g. a
+// dicomweb:// URL captured in wellally.Attachment.
+type ImagingStudySeriesInstance struct {
+	Title string `json:"title,omitempty"`
+}
+
+// FHIRResourceType implements Resource.
+func (s *ImagingStudy) FHIRResourceType() ResourceType { return ResourceTypeImagingStudy }
+
+// ImagingReportDiagnosticReport carries the narrative portions of a
+// wellally.ImagingReport (Findings/Impression) that ImagingStudy has no
+// element for.
+type ImagingReportDiagnosticReport struct {
+	ResourceType ResourceType `json:"resourceType"`
+	ID           string       `json:"id"`
+	Status       string       `json:"status"`
+	Subject      *Reference   `json:"subject,omitempty"`
+	ImagingStudy []Reference  `json:"imagingStudy,omitempty"`
+	Conclusion   string       `json:"conclusion,omitempty"`
+	Presented    []string     `json:"presentedForm,omitempty"`
+}
+
+// FHIRResourceType implements Resource.
+func (d *ImagingReportDiagnosticReport) FHIRResourceType() ResourceType {
+	return ResourceTypeDiagnosticReport
+}
+
+// ImagingReportToFHIR converts a wellally.ImagingReport into a FHIR R4
+// ImagingStudy. Call ImagingReportToFHIRReport for the narrative report.
+func ImagingReportToFHIR(report wellally.ImagingReport) *ImagingStudy {
+	study := &ImagingStudy{
+		ResourceType: ResourceTypeImagingStudy,
+		ID:           report.ID,
+		Status:       "available",
+		Subject:      NewReference(ResourceTypePatient, report.PatientID),
+		Started:      report.ReportedAt.Format(fhirDateTimeLayout),
+		Modality:     []wellally.Coding{{System: report.Modality.System, Code: string(report.Modality.Code), Display: report.Modality.Display}},
+	}
+	if report.StudyInstanceUID != nil {
+		study.Identifier = append(study.Identifier, wellally.Identifier{
+			System: dicomStudyInstanceUIDSystem,
+			Value:  *report.StudyInstanceUID,
+		})
+	}
+	series := ImagingStudySeries{BodySite: report.BodySite}
+	for _, a := range report.Attachments {
+		if a.URL != nil {
+			series.Instance = append(series.Instance, ImagingStudySeriesInstance{Title: *a.URL})
+		}
+	}
+	study.Series = append(study.Series, series)
+	return study
+}
+
+// ImagingReportToFHIRReport builds the companion DiagnosticReport carrying
+// Findings/Impression, since ImagingStudy itself has no narrative element.
+func ImagingReportToFHIRReport(report wellally.ImagingReport, study *ImagingStudy) *ImagingReportDiagnosticReport {
+	dr := &ImagingReportDiagnosticReport{
+		ResourceType: ResourceTypeDiagnosticReport,
+		ID:           report.ID,
+		Status:       "final",
+		Subject:      NewReference(ResourceTypePatient, report.PatientID),
+		ImagingStudy: []Reference{*NewReference(ResourceTypeImagingStudy, study.ID)},
+		Presented:    report.Findings,
+	}
+	if report.Impression != nil {
+		dr.Conclusion = *report.Impression
+	}
+	return dr
+}
+
+// ImagingStudyToImagingReport converts a FHIR R4 ImagingStudy back into a
+// wellally.ImagingReport. Findings/Impression are left empty; merge in the
+// companion DiagnosticReport with MergeImagingReportNarrative if available.
+func ImagingStudyToImagingReport(study *ImagingStudy) (wellally.ImagingReport, error) {
+	started, err := parseFHIRDate(study.Started)
+	if err != nil {
+		return wellally.ImagingReport{}, fmt.Errorf("fhir: parsing ImagingStudy.started: %w", err)
+	}
+	report := wellally.ImagingReport{
+		ID:         study.ID,
+		PatientID:  study.Subject.ID(),
+		ReportedAt: started,
+	}
+	studyInstanceUID := study.ID
+	for _, id := range study.Identifier {
+		if id.System == dicomStudyInstanceUIDSystem {
+			studyInstanceUID = id.Value
+			break
+		}
+	}
+	report.StudyInstanceUID = &studyInstanceUID
+	if len(study.Modality) > 0 {
+		m := study.Modality[0]
+		report.Modality = wellally.Modality{System: m.System, Code: wellally.ModalityCode(m.Code), Display: m.Display}
+	}
+	if len(study.Series) > 0 {
+		report.BodySite = study.Series[0].BodySite
+		for _, instance := range study.Series[0].Instance {
+			title := instance.Title
+			report.Attachments = append(report.Attachments, wellally.Attachment{URL: &title, Type: strPtr("application/dicom")})
+		}
+	}
+	return report, nil
+}
+
+// MergeImagingReportNarrative copies Findings/Impression from a companion
+// DiagnosticReport into an already-converted wellally.ImagingReport.
+func MergeImagingReportNarrative(report *wellally.ImagingReport, dr *ImagingReportDiagnosticReport) {
+	report.Findings = dr.Presented
+	if dr.Conclusion != "" {
+		conclusion := dr.Conclusion
+		report.Impression = &conclusion
+	}
+}
@@ -0,0 +1,143 @@
+package fhir
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// MedicationStatement is the FHIR R4 MedicationStatement resource, used for
+// wellally.MedicationRecord entries that describe medication already taken.
+type MedicationStatement struct {
+	ResourceType    ResourceType               `json:"resourceType"`
+	ID              string                     `json:"id"`
+	Status          string                     `json:"status"`
+	Medication      wellally.CodeableConcept   `json:"medicationCodeableConcept"`
+	Subject         *Reference                 `json:"subject,omitempty"`
+	EffectivePeriod *wellally.Period           `json:"effectivePeriod,omitempty"`
+	Dosage          []MedicationDosage         `json:"dosage,omitempty"`
+	ReasonCode      []wellally.CodeableConcept `json:"reasonCode,omitempty"`
+}
+
+// MedicationRequest is the FHIR R4 MedicationRequest resource, used for
+// wellally.MedicationRecord entries that represent an active order.
+type MedicationRequest struct {
+	ResourceType      ResourceType               `json:"resourceType"`
+	ID                string                     `json:"id"`
+	Status            string                     `json:"status"`
+	Intent            string                     `json:"intent"`
+	Medication        wellally.CodeableConcept   `json:"medicationCodeableConcept"`
+	Subject           *Reference                 `json:"subject,omitempty"`
+	DosageInstruction []MedicationDosage         `json:"dosageInstruction,omitempty"`
+	ReasonCode        []wellally.CodeableConcept `json:"reasonCode,omitempty"`
+}
+
+// MedicationDosage mirrors FHIR Dosage, restricted to the elements wellally
+// carries (route, quantity, duration, free-text instructions).
+type MedicationDosage struct {
+	Text           string             `json:"text,omitempty"`
+	Route          *wellally.Route    `json:"route,omitempty"`
+	DoseQuantity   *wellally.Quantity `json:"doseQuantity,omitempty"`
+	DurationInDays *int               `json:"durationInDays,omitempty"`
+}
+
+// FHIRResourceType implements Resource.
+func (m *MedicationStatement) FHIRResourceType() ResourceType {
+	return ResourceTypeMedicationStatement
+}
+
+// FHIRResourceType implements Resource.
+func (m *MedicationRequest) FHIRResourceType() ResourceType { return ResourceTypeMedicationRequest }
+
+func medicationRecordToDosage(record wellally.MedicationRecord) MedicationDosage {
+	dosage := MedicationDosage{
+		Route:          &record.Route,
+		DoseQuantity:   &wellally.Quantity{Value: record.Dosage.Value, Unit: wellally.UCUMUnit(record.Dosage.Unit)},
+		DurationInDays: record.DurationDays,
+	}
+	if record.Instructions != nil {
+		dosage.Text = *record.Instructions
+	}
+	return dosage
+}
+
+// MedicationRecordToMedicationStatement converts a wellally.MedicationRecord
+// into a FHIR R4 MedicationStatement, the right resource when the record
+// reflects medication the patient is reported to be taking.
+func MedicationRecordToMedicationStatement(record wellally.MedicationRecord) *MedicationStatement {
+	stmt := &MedicationStatement{
+		ResourceType:    ResourceTypeMedicationStatement,
+		ID:              record.ID,
+		Status:          "active",
+		Medication:      wellally.CodeableConcept{Coding: []wellally.Coding{record.Medication}},
+		Subject:         NewReference(ResourceTypePatient, record.PatientID),
+		EffectivePeriod: &wellally.Period{Start: &record.StartDate, End: record.EndDate},
+		Dosage:          []MedicationDosage{medicationRecordToDosage(record)},
+	}
+	if record.Indication != nil {
+		stmt.ReasonCode = []wellally.CodeableConcept{*record.Indication}
+	}
+	if record.EndDate == nil {
+		stmt.Status = "active"
+	} else {
+		stmt.Status = "completed"
+	}
+	return stmt
+}
+
+// MedicationRecordToMedicationRequest converts a wellally.MedicationRecord
+// into a FHIR R4 MedicationRequest, the right resource when the record
+// reflects a prescriber's order rather than an observed statement.
+func MedicationRecordToMedicationRequest(record wellally.MedicationRecord) *MedicationRequest {
+	req := &MedicationRequest{
+		ResourceType:      ResourceTypeMedicationRequest,
+		ID:                record.ID,
+		Status:            "active",
+		Intent:            "order",
+		Medication:        wellally.CodeableConcept{Coding: []wellally.Coding{record.Medication}},
+		Subject:           NewReference(ResourceTypePatient, record.PatientID),
+		DosageInstruction: []MedicationDosage{medicationRecordToDosage(record)},
+	}
+	if record.Indication != nil {
+		req.ReasonCode = []wellally.CodeableConcept{*record.Indication}
+	}
+	return req
+}
+
+func dosageToMedicationRecord(dosage MedicationDosage, record *wellally.MedicationRecord) {
+	if dosage.Route != nil {
+		record.Route = *dosage.Route
+	}
+	if dosage.DoseQuantity != nil {
+		record.Dosage = wellally.Dosage{Value: dosage.DoseQuantity.Value, Unit: string(dosage.DoseQuantity.Unit)}
+	}
+	record.DurationDays = dosage.DurationInDays
+	if dosage.Text != "" {
+		text := dosage.Text
+		record.Instructions = &text
+	}
+}
+
+// MedicationStatementToMedicationRecord converts a FHIR R4
+// MedicationStatement back into a wellally.MedicationRecord.
+func MedicationStatementToMedicationRecord(stmt *MedicationStatement) (wellally.MedicationRecord, error) {
+	if len(stmt.Medication.Coding) == 0 {
+		return wellally.MedicationRecord{}, fmt.Errorf("fhir: MedicationStatement %s has no medicationCodeableConcept.coding", stmt.ID)
+	}
+	record := wellally.MedicationRecord{
+		ID:         stmt.ID,
+		PatientID:  stmt.Subject.ID(),
+		Medication: stmt.Medication.Coding[0],
+	}
+	if stmt.EffectivePeriod != nil && stmt.EffectivePeriod.Start != nil {
+		record.StartDate = *stmt.EffectivePeriod.Start
+		record.EndDate = stmt.EffectivePeriod.End
+	}
+	if len(stmt.Dosage) > 0 {
+		dosageToMedicationRecord(stmt.Dosage[0], &record)
+	}
+	if len(stmt.ReasonCode) > 0 {
+		record.Indication = &stmt.ReasonCode[0]
+	}
+	return record, nil
+}
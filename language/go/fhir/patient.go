@@ -0,0 +1,87 @@
+package fhir
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// Patient is the FHIR R4 Patient resource, restricted to the elements
+// wellally.Person carries.
+type Patient struct {
+	ResourceType  ResourceType              `json:"resourceType"`
+	ID            string                    `json:"id"`
+	Identifier    []wellally.Identifier     `json:"identifier,omitempty"`
+	Name          []wellally.HumanName      `json:"name,omitempty"`
+	Telecom       []wellally.ContactPoint   `json:"telecom,omitempty"`
+	Gender        string                    `json:"gender,omitempty"`
+	BirthDate     string                    `json:"birthDate,omitempty"`
+	Address       []wellally.Address        `json:"address,omitempty"`
+	MaritalStatus *wellally.CodeableConcept `json:"maritalStatus,omitempty"`
+	Communication []PatientCommunication    `json:"communication,omitempty"`
+}
+
+// PatientCommunication carries one entry of Patient.communication.
+type PatientCommunication struct {
+	Language wellally.CodeableConcept `json:"language"`
+}
+
+// FHIRResourceType implements Resource.
+func (p *Patient) FHIRResourceType() ResourceType { return ResourceTypePatient }
+
+// PersonToPatient converts a wellally.Person into a FHIR R4 Patient.
+func PersonToPatient(p wellally.Person) *Patient {
+	patient := &Patient{
+		ResourceType:  ResourceTypePatient,
+		ID:            p.ID,
+		Identifier:    p.Identifier,
+		Name:          p.Name,
+		Telecom:       p.Telecom,
+		Address:       p.Address,
+		MaritalStatus: p.MaritalStatus,
+	}
+	if !p.BirthDate.IsZero() {
+		patient.BirthDate = p.BirthDate.Format(fhirDateLayout)
+	}
+	if p.Gender != nil {
+		patient.Gender = string(*p.Gender)
+	}
+	for _, lang := range p.Language {
+		patient.Communication = append(patient.Communication, PatientCommunication{
+			Language: wellally.CodeableConcept{Text: strPtr(lang)},
+		})
+	}
+	return patient
+}
+
+// PatientToPerson converts a FHIR R4 Patient back into a wellally.Person.
+func PatientToPerson(patient *Patient) (wellally.Person, error) {
+	p := wellally.Person{
+		ID:            patient.ID,
+		ResourceType:  "Person",
+		Name:          patient.Name,
+		Identifier:    patient.Identifier,
+		Telecom:       patient.Telecom,
+		Address:       patient.Address,
+		MaritalStatus: patient.MaritalStatus,
+	}
+	if patient.BirthDate != "" {
+		t, err := parseFHIRDate(patient.BirthDate)
+		if err != nil {
+			return wellally.Person{}, fmt.Errorf("fhir: parsing Patient.birthDate: %w", err)
+		}
+		p.BirthDate = t
+	}
+	if patient.Gender != "" {
+		g := wellally.Gender(patient.Gender)
+		p.Gender = &g
+	}
+	for _, c := range patient.Communication {
+		if c.Language.Text != nil {
+			p.Language = append(p.Language, *c.Language.Text)
+		}
+	}
+	return p, nil
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,151 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ResourceType identifies the FHIR resource kind.
+type ResourceType string
+
+const (
+	ResourceTypePatient             ResourceType = "Patient"
+	ResourceTypeDiagnosticReport    ResourceType = "DiagnosticReport"
+	ResourceTypeObservation         ResourceType = "Observation"
+	ResourceTypeImagingStudy        ResourceType = "ImagingStudy"
+	ResourceTypeMedicationStatement ResourceType = "MedicationStatement"
+	ResourceTypeMedicationRequest   ResourceType = "MedicationRequest"
+	ResourceTypeFamilyMemberHistory ResourceType = "FamilyMemberHistory"
+	ResourceTypeBundle              ResourceType = "Bundle"
+)
+
+// Resource is implemented by every FHIR resource this package produces.
+type Resource interface {
+	// FHIRResourceType returns the resource's `resourceType` discriminator.
+	FHIRResourceType() ResourceType
+}
+
+// Reference is a FHIR Reference data type, e.g. {"reference": "Patient/123"}.
+type Reference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+// NewReference builds a local Reference of the form "{resourceType}/{id}".
+func NewReference(resourceType ResourceType, id string) *Reference {
+	if id == "" {
+		return nil
+	}
+	return &Reference{Reference: fmt.Sprintf("%s/%s", resourceType, id)}
+}
+
+// ID extracts the trailing id segment from a "{resourceType}/{id}" reference.
+// It returns an empty string if ref is nil or not in that form.
+func (r *Reference) ID() string {
+	if r == nil {
+		return ""
+	}
+	for i := len(r.Reference) - 1; i >= 0; i-- {
+		if r.Reference[i] == '/' {
+			return r.Reference[i+1:]
+		}
+	}
+	return ""
+}
+
+// UnsupportedTypeError is returned by ToFHIR/FromFHIR when given a value
+// this package does not know how to convert.
+type UnsupportedTypeError struct {
+	Value any
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("fhir: unsupported type %T", e.Value)
+}
+
+// Bundle is a minimal FHIR R4 Bundle, used by ToFHIR/FromFHIR to carry the
+// conversions that don't fit a single resource: a LabReport becomes a
+// DiagnosticReport plus one Observation per result, an ImagingReport
+// becomes an ImagingStudy plus a companion DiagnosticReport carrying its
+// narrative, and a FamilyHealthTree becomes one FamilyMemberHistory per
+// member.
+type Bundle struct {
+	ResourceType ResourceType  `json:"resourceType"`
+	Type         string        `json:"type"`
+	Entry        []BundleEntry `json:"entry"`
+}
+
+// FHIRResourceType implements Resource.
+func (b *Bundle) FHIRResourceType() ResourceType { return ResourceTypeBundle }
+
+// BundleEntry wraps one resource in a Bundle.
+type BundleEntry struct {
+	Resource Resource `json:"resource"`
+}
+
+// NewBundle wraps resources in a "collection" Bundle, skipping any nil
+// entries. The result is never nil, even when resources is empty.
+func NewBundle(resources ...Resource) *Bundle {
+	bundle := &Bundle{ResourceType: ResourceTypeBundle, Type: "collection"}
+	for _, res := range resources {
+		if res == nil {
+			continue
+		}
+		bundle.Entry = append(bundle.Entry, BundleEntry{Resource: res})
+	}
+	return bundle
+}
+
+// UnmarshalJSON decodes a bundle entry by first reading its resource's
+// resourceType discriminator, then unmarshaling into the matching
+// concrete Resource type.
+//
+// ResourceTypeDiagnosticReport is ambiguous: both a LabReport's
+// *DiagnosticReport and an ImagingReport's companion
+// *ImagingReportDiagnosticReport carry that same resourceType. They are
+// told apart by the imagingStudy field, which only the latter has.
+func (e *BundleEntry) UnmarshalJSON(data []byte) error {
+	var envelope struct {
+		Resource json.RawMessage `json:"resource"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("fhir: decoding bundle entry: %w", err)
+	}
+
+	var discriminator struct {
+		ResourceType ResourceType    `json:"resourceType"`
+		ImagingStudy json.RawMessage `json:"imagingStudy"`
+	}
+	if err := json.Unmarshal(envelope.Resource, &discriminator); err != nil {
+		return fmt.Errorf("fhir: decoding bundle entry resourceType: %w", err)
+	}
+
+	var res Resource
+	switch discriminator.ResourceType {
+	case ResourceTypePatient:
+		res = &Patient{}
+	case ResourceTypeDiagnosticReport:
+		if discriminator.ImagingStudy != nil {
+			res = &ImagingReportDiagnosticReport{}
+		} else {
+			res = &DiagnosticReport{}
+		}
+	case ResourceTypeObservation:
+		res = &Observation{}
+	case ResourceTypeImagingStudy:
+		res = &ImagingStudy{}
+	case ResourceTypeMedicationStatement:
+		res = &MedicationStatement{}
+	case ResourceTypeMedicationRequest:
+		res = &MedicationRequest{}
+	case ResourceTypeFamilyMemberHistory:
+		res = &FamilyMemberHistory{}
+	default:
+		return fmt.Errorf("fhir: unknown resourceType %q in bundle entry", discriminator.ResourceType)
+	}
+	if err := json.Unmarshal(envelope.Resource, res); err != nil {
+		return fmt.Errorf("fhir: decoding %s bundle entry: %w", discriminator.ResourceType, err)
+	}
+	e.Resource = res
+	return nil
+}
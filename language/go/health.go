@@ -34,9 +34,9 @@ type Person struct {
 	// Unique person identifier (UUID/ULID)
 	ID string `json:"id"`
 	// Resource type (always "Person")
-	ResourceType string `json:"resourceType"`
+	ResourceType string `json:"resourceType,omitempty"`
 	// Person name(s)
-	Name []HumanName `json:"name"`
+	Name []HumanName `json:"name,omitempty"`
 	// Date of birth
 	BirthDate time.Time `json:"birthDate"`
 	// External identifiers (MRN, national ID, etc.)
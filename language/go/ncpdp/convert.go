@@ -0,0 +1,131 @@
+package ncpdp
+
+import (
+	"strconv"
+	"time"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// RxNormSystem is the terminology system URI NCPDP's DrugDBCode maps to
+// when its Qualifier is "RXCUI".
+const RxNormSystem = "http://www.nlm.nih.gov/research/umls/rxnorm"
+
+const ncpdpDateLayout = "20060102"
+
+// MessageToRecords extracts every prescription carried by msg
+// (NewRx/RxFill/RxHistoryResponse, whichever is populated) into
+// wellally.MedicationRecord values.
+func MessageToRecords(msg *Message) []wellally.MedicationRecord {
+	var records []wellally.MedicationRecord
+	switch {
+	case msg.Body.NewRx != nil:
+		rx := msg.Body.NewRx
+		records = append(records, medicationToRecord(msg.Header.MessageID, rx.Patient, rx.MedicationPrescribed))
+	case msg.Body.RxFill != nil:
+		fill := msg.Body.RxFill
+		records = append(records, medicationToRecord(msg.Header.MessageID, fill.Patient, fill.MedicationDispensed))
+	case msg.Body.RxHistoryResponse != nil:
+		history := msg.Body.RxHistoryResponse
+		for i, med := range history.Medication {
+			id := msg.Header.MessageID
+			if id != "" {
+				id = id + "-" + strconv.Itoa(i+1)
+			}
+			records = append(records, medicationToRecord(id, history.Patient, med))
+		}
+	}
+	return records
+}
+
+func medicationToRecord(id string, patient Patient, med MedicationPrescribed) wellally.MedicationRecord {
+	record := wellally.MedicationRecord{
+		ID:         id,
+		PatientID:  patientID(patient),
+		Medication: drugCodedToCoding(med.DrugCoded),
+		Route:      codedValueToRoute(med.RouteOfAdministration),
+	}
+	if value, err := strconv.ParseFloat(med.Quantity.Value, 64); err == nil {
+		record.Dosage = wellally.Dosage{Value: value, Unit: med.Quantity.QuantityUnitOfMeasure.Code}
+	}
+	if days, err := strconv.Atoi(med.DaysSupply); err == nil {
+		record.DurationDays = &days
+	}
+	if start, err := time.Parse(ncpdpDateLayout, med.WrittenDate); err == nil {
+		record.StartDate = start
+	}
+	if med.Sig.SigText != "" {
+		sig := med.Sig.SigText
+		record.Instructions = &sig
+	}
+	return record
+}
+
+func patientID(patient Patient) string {
+	if len(patient.Identification) > 0 {
+		return patient.Identification[0].Value
+	}
+	return ""
+}
+
+func drugCodedToCoding(drug DrugCoded) wellally.Coding {
+	if drug.DrugDBCode.Qualifier == "RXCUI" && drug.DrugDBCode.Code != "" {
+		return wellally.Coding{System: RxNormSystem, Code: drug.DrugDBCode.Code, Display: displayPtr(drug.DrugDescription)}
+	}
+	return wellally.Coding{System: drug.ProductCode.Qualifier, Code: drug.ProductCode.Code, Display: displayPtr(drug.DrugDescription)}
+}
+
+func codedValueToRoute(route CodedValue) wellally.Route {
+	return wellally.Route{System: route.Qualifier, Code: route.Code}
+}
+
+func displayPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// RecordsToMessage builds an NCPDP SCRIPT RxHistoryResponse <Message>
+// from records, the reverse of MessageToRecords for history traffic.
+// The first record's PatientID is written once, as the message Patient's
+// single Identification, since wellally.MedicationRecord carries no
+// patient demographics of its own; the PatientID of later records is not
+// written.
+func RecordsToMessage(messageID string, records []wellally.MedicationRecord) *Message {
+	history := &RxHistoryResponse{}
+	for _, record := range records {
+		history.Medication = append(history.Medication, recordToMedication(record))
+	}
+	if len(records) > 0 {
+		history.Patient = Patient{Identification: []Identification{{Value: records[0].PatientID}}}
+	}
+	return &Message{
+		Header: Header{MessageID: messageID},
+		Body:   Body{RxHistoryResponse: history},
+	}
+}
+
+func recordToMedication(record wellally.MedicationRecord) MedicationPrescribed {
+	med := MedicationPrescribed{
+		DrugCoded: DrugCoded{
+			DrugDBCode: CodedValue{Code: record.Medication.Code, Qualifier: "RXCUI"},
+		},
+		Quantity: Quantity{
+			Value:                 strconv.FormatFloat(record.Dosage.Value, 'f', -1, 64),
+			QuantityUnitOfMeasure: CodedValue{Code: record.Dosage.Unit},
+		},
+		RouteOfAdministration: CodedValue{Code: record.Route.Code, Qualifier: record.Route.System},
+		WrittenDate:           record.StartDate.Format(ncpdpDateLayout),
+	}
+	if record.Medication.Display != nil {
+		med.DrugCoded.DrugDescription = *record.Medication.Display
+	}
+	if record.DurationDays != nil {
+		med.DaysSupply = strconv.Itoa(*record.DurationDays)
+	}
+	if record.Instructions != nil {
+		med.Sig.SigText = *record.Instructions
+	}
+	return med
+}
@@ -0,0 +1,26 @@
+package ncpdp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Decode parses an NCPDP SCRIPT 2017071 <Message> document from r.
+func Decode(r io.Reader) (*Message, error) {
+	var msg Message
+	if err := xml.NewDecoder(r).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("ncpdp: decoding Message: %w", err)
+	}
+	return &msg, nil
+}
+
+// Encode writes msg as an NCPDP SCRIPT 2017071 <Message> document to w.
+func Encode(w io.Writer, msg *Message) error {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(msg); err != nil {
+		return fmt.Errorf("ncpdp: encoding Message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Package ncpdp decodes and encodes NCPDP SCRIPT 2017071 e-prescribing
+// messages (NewRx, RxFill, RxHistoryResponse) and bridges them to the
+// wellally domain model.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+// Spec: NCPDP SCRIPT Standard, Implementation Guide Version 2017071
+//
+// Decode reads a <Message> document into a Message value; MessageToRecords
+// then maps its NewRx/RxFill/RxHistoryResponse entries into
+// []wellally.MedicationRecord (RxNorm DrugCoded -> Medication, Quantity and
+// DaysSupply -> Dosage/DurationDays, Sig -> Instructions, route codes ->
+// Route). RecordsToMessage and Encode perform the reverse, for systems
+// that need to originate SCRIPT traffic from wellally data.
+package ncpdp
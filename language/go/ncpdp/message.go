@@ -0,0 +1,124 @@
+package ncpdp
+
+import "encoding/xml"
+
+// Message is the root <Message> element of an NCPDP SCRIPT 2017071
+// document, restricted to the elements this package reads and writes.
+type Message struct {
+	XMLName xml.Name `xml:"Message"`
+	Header  Header   `xml:"Header"`
+	Body    Body     `xml:"Body"`
+}
+
+// Header is the NCPDP SCRIPT transport envelope.
+type Header struct {
+	To        string `xml:"To"`
+	From      string `xml:"From"`
+	MessageID string `xml:"MessageID"`
+	SentTime  string `xml:"SentTime"`
+}
+
+// Body carries exactly one of NewRx, RxFill, or RxHistoryResponse, as in
+// a real SCRIPT transaction.
+type Body struct {
+	NewRx             *NewRx             `xml:"NewRx"`
+	RxFill            *RxFill            `xml:"RxFill"`
+	RxHistoryResponse *RxHistoryResponse `xml:"RxHistoryResponse"`
+}
+
+// Patient is the NCPDP SCRIPT <Patient> block.
+type Patient struct {
+	Name           PatientName      `xml:"HumanPatient>Name"`
+	Gender         string           `xml:"HumanPatient>Gender"`
+	DateOfBirth    string           `xml:"HumanPatient>DateOfBirth>Date"`
+	Identification []Identification `xml:"Identification"`
+}
+
+// PatientName is the NCPDP SCRIPT <Name> block.
+type PatientName struct {
+	LastName  string `xml:"LastName"`
+	FirstName string `xml:"FirstName"`
+}
+
+// Identification is one NCPDP SCRIPT patient/prescriber identifier pair.
+type Identification struct {
+	Qualifier string `xml:"Qualifier"`
+	Value     string `xml:"IDValue"`
+}
+
+// Pharmacy is the NCPDP SCRIPT <Pharmacy> block, mapped loosely onto a
+// Facility-like shape: an identifier plus a display name.
+type Pharmacy struct {
+	Identification []Identification `xml:"Identification"`
+	StoreName      string           `xml:"BusinessName"`
+}
+
+// Prescriber is the NCPDP SCRIPT <Prescriber> block, mapped loosely onto
+// a Performer-like shape: an identifier plus a display name.
+type Prescriber struct {
+	Identification []Identification `xml:"Identification"`
+	Name           PatientName      `xml:"Name"`
+}
+
+// CodedValue is the recurring NCPDP SCRIPT {Code, Qualifier} pair, used
+// for drug codes, units of measure, and route codes.
+type CodedValue struct {
+	Code      string `xml:"Code"`
+	Qualifier string `xml:"Qualifier"`
+}
+
+// DrugCoded is the NCPDP SCRIPT <DrugCoded> block. DrugDBCode carries the
+// RxNorm code when Qualifier is "RXCUI".
+type DrugCoded struct {
+	ProductCode     CodedValue `xml:"ProductCode"`
+	DrugDBCode      CodedValue `xml:"DrugDBCode"`
+	Strength        string     `xml:"Strength>StrengthValue"`
+	DrugDescription string     `xml:"DrugDescription"`
+}
+
+// Quantity is the NCPDP SCRIPT <Quantity> block.
+type Quantity struct {
+	Value                 string     `xml:"Value"`
+	QuantityUnitOfMeasure CodedValue `xml:"QuantityUnitOfMeasure"`
+}
+
+// Sig is the NCPDP SCRIPT <Sig> block; SigText is the human-readable
+// instructions, the only part wellally.MedicationRecord has room for.
+type Sig struct {
+	SigText string `xml:"SigText"`
+}
+
+// MedicationPrescribed is the NCPDP SCRIPT <MedicationPrescribed> block
+// carried by NewRx.
+type MedicationPrescribed struct {
+	DrugCoded             DrugCoded  `xml:"DrugCoded"`
+	Quantity              Quantity   `xml:"Quantity"`
+	DaysSupply            string     `xml:"DaysSupply"`
+	Sig                   Sig        `xml:"Sig"`
+	RouteOfAdministration CodedValue `xml:"RouteOfAdministration"`
+	WrittenDate           string     `xml:"WrittenDate>Date"`
+	Note                  string     `xml:"Note"`
+}
+
+// NewRx is the NCPDP SCRIPT new-prescription transaction.
+type NewRx struct {
+	Patient              Patient              `xml:"Patient"`
+	Pharmacy             Pharmacy             `xml:"Pharmacy"`
+	Prescriber           Prescriber           `xml:"Prescriber"`
+	MedicationPrescribed MedicationPrescribed `xml:"MedicationPrescribed"`
+}
+
+// RxFill is the NCPDP SCRIPT fill-status transaction.
+type RxFill struct {
+	Patient             Patient              `xml:"Patient"`
+	Pharmacy            Pharmacy             `xml:"Pharmacy"`
+	MedicationDispensed MedicationPrescribed `xml:"MedicationDispensed"`
+	LastFillDate        string               `xml:"LastFillDate>Date"`
+}
+
+// RxHistoryResponse is the NCPDP SCRIPT medication-history transaction,
+// carrying zero or more prior NewRx-shaped entries.
+type RxHistoryResponse struct {
+	Patient    Patient                `xml:"Patient"`
+	Medication []MedicationPrescribed `xml:"MedicationDispensed"`
+}
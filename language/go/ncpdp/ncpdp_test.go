@@ -0,0 +1,98 @@
+package ncpdp
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// TestDecodeNewRx checks that Decode plus MessageToRecords extracts a
+// wellally.MedicationRecord from a fixture NewRx SCRIPT document.
+func TestDecodeNewRx(t *testing.T) {
+	data, err := os.ReadFile("testdata/newrx.xml")
+	if err != nil {
+		t.Fatalf("reading testdata/newrx.xml: %v", err)
+	}
+
+	msg, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if msg.Body.NewRx == nil {
+		t.Fatal("Decode: Body.NewRx is nil")
+	}
+
+	records := MessageToRecords(msg)
+	if len(records) != 1 {
+		t.Fatalf("MessageToRecords: got %d records, want 1", len(records))
+	}
+	record := records[0]
+
+	want := wellally.MedicationRecord{
+		ID:           "MSG-0001",
+		PatientID:    "person-1",
+		Medication:   wellally.Coding{System: RxNormSystem, Code: "860975", Display: displayPtr("Metformin 500 MG Oral Tablet")},
+		Dosage:       wellally.Dosage{Value: 60, Unit: "C48542"},
+		Route:        wellally.Route{System: "HL70162", Code: "PO"},
+		StartDate:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		DurationDays: intPtr(30),
+		Instructions: displayPtr("Take one tablet by mouth twice daily"),
+	}
+	if record.PatientID != want.PatientID ||
+		!reflect.DeepEqual(record.Medication, want.Medication) ||
+		record.Dosage != want.Dosage ||
+		record.Route != want.Route ||
+		!record.StartDate.Equal(want.StartDate) ||
+		record.DurationDays == nil || *record.DurationDays != *want.DurationDays ||
+		record.Instructions == nil || *record.Instructions != *want.Instructions {
+		t.Errorf("MessageToRecords: got %+v, want %+v", record, want)
+	}
+}
+
+// TestEncodeDecodeRoundTrip checks that RecordsToMessage and Encode produce
+// a document that Decode and MessageToRecords read back into the same
+// records, so the reverse direction isn't only exercised at encode time.
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("testdata/newrx.xml")
+	if err != nil {
+		t.Fatalf("reading testdata/newrx.xml: %v", err)
+	}
+	msg, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	records := MessageToRecords(msg)
+
+	history := RecordsToMessage("MSG-0002", records)
+	var buf bytes.Buffer
+	if err := Encode(&buf, history); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	roundTripped, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode(encoded): %v", err)
+	}
+	if roundTripped.Body.RxHistoryResponse == nil {
+		t.Fatal("Decode(encoded): Body.RxHistoryResponse is nil")
+	}
+
+	got := MessageToRecords(roundTripped)
+	if len(got) != len(records) {
+		t.Fatalf("MessageToRecords(round-tripped): got %d records, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if !reflect.DeepEqual(got[i].Medication, records[i].Medication) ||
+			got[i].Dosage != records[i].Dosage ||
+			got[i].Route != records[i].Route ||
+			!got[i].StartDate.Equal(records[i].StartDate) {
+			t.Errorf("record %d: got %+v, want %+v", i, got[i], records[i])
+		}
+	}
+}
+
+func intPtr(i int) *int { return &i }
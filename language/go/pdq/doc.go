@@ -0,0 +1,16 @@
+// Package pdq implements an IHE PIXm/PDQm-style patient identifier
+// consumer on top of the wellally domain model.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+// Spec: https://profiles.ihe.net/ITI/PDQm/ and https://profiles.ihe.net/ITI/PIXm/
+//
+// Person.Identifier already models the assigning-authority/value pairs
+// PDQm and PIXm exchange, so Resolver treats that slice as the unit of
+// cross-reference: Resolve performs a demographics-based PDQm query and
+// CrossReference performs a PIXm ($ihe-pix) lookup to reconcile an
+// Identifier from one assigning system against others. HTTPResolver
+// speaks FHIR-based PIXm/PDQm over a pluggable http.RoundTripper;
+// InMemoryResolver serves the same interface from an in-process fixture
+// for tests.
+package pdq
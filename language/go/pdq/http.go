@@ -0,0 +1,155 @@
+package pdq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/huifer/wellally-schemas/language/go/fhir"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// HTTPResolver is a Resolver backed by a FHIR server speaking IHE
+// PIXm/PDQm. Transport defaults to http.DefaultTransport when nil, which
+// lets callers swap in a RoundTripper for auth, retries, or tests.
+type HTTPResolver struct {
+	// BaseURL is the FHIR endpoint, e.g. "https://mpi.example.org/fhir".
+	BaseURL string
+	// Transport is the pluggable HTTP transport used for every request.
+	Transport http.RoundTripper
+}
+
+func (r *HTTPResolver) client() *http.Client {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{Transport: transport}
+}
+
+// fhirBundle is the minimal subset of a FHIR Bundle this resolver reads.
+type fhirBundle struct {
+	Entry []struct {
+		Resource json.RawMessage `json:"resource"`
+	} `json:"entry"`
+}
+
+// Resolve issues a PDQm Patient search built from query's demographics.
+func (r *HTTPResolver) Resolve(ctx context.Context, query PDQQuery) ([]wellally.Person, error) {
+	params := url.Values{}
+	if query.Identifier != nil {
+		params.Set("identifier", query.Identifier.System+"|"+query.Identifier.Value)
+	}
+	if query.FamilyName != "" {
+		params.Set("family", query.FamilyName)
+	}
+	if query.GivenName != "" {
+		params.Set("given", query.GivenName)
+	}
+	if query.BirthDate != "" {
+		params.Set("birthdate", query.BirthDate)
+	}
+	if query.Gender != nil {
+		params.Set("gender", string(*query.Gender))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+"/Patient?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("pdq: building PDQm request: %w", err)
+	}
+	req.Header.Set("Accept", "application/fhir+json")
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pdq: PDQm request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pdq: PDQm request returned status %d", resp.StatusCode)
+	}
+
+	var bundle fhirBundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("pdq: decoding PDQm Bundle: %w", err)
+	}
+
+	people := make([]wellally.Person, 0, len(bundle.Entry))
+	for _, entry := range bundle.Entry {
+		var patient fhir.Patient
+		if err := json.Unmarshal(entry.Resource, &patient); err != nil {
+			return nil, fmt.Errorf("pdq: decoding Patient entry: %w", err)
+		}
+		person, err := fhir.PatientToPerson(&patient)
+		if err != nil {
+			return nil, fmt.Errorf("pdq: converting Patient to Person: %w", err)
+		}
+		people = append(people, person)
+	}
+	return people, nil
+}
+
+// pixmIdentifier is the minimal subset of a FHIR Identifier that
+// $ihe-pix's valueIdentifier carries.
+type pixmIdentifier struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+}
+
+// pixmParameters is the minimal subset of the FHIR Parameters resource
+// returned by the $ihe-pix operation that CrossReference needs.
+//
+// valueIdentifier is a structured Identifier object per the FHIR
+// Parameters schema, not a "system|value" string like the identifier
+// search parameter Resolve builds - decoding it as a string would read
+// empty system/value out of any conformant server response.
+type pixmParameters struct {
+	Parameter []struct {
+		Name            string         `json:"name"`
+		ValueIdentifier pixmIdentifier `json:"valueIdentifier"`
+	} `json:"parameter"`
+}
+
+// CrossReference calls the FHIR $ihe-pix operation to reconcile id
+// against each of targetSystems.
+func (r *HTTPResolver) CrossReference(ctx context.Context, id wellally.Identifier, targetSystems []string) ([]wellally.Identifier, error) {
+	params := url.Values{}
+	params.Set("sourceIdentifier", id.System+"|"+id.Value)
+	for _, system := range targetSystems {
+		params.Add("targetSystem", system)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+"/Patient/$ihe-pix?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("pdq: building PIXm request: %w", err)
+	}
+	req.Header.Set("Accept", "application/fhir+json")
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pdq: PIXm request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pdq: PIXm request returned status %d", resp.StatusCode)
+	}
+
+	var out pixmParameters
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("pdq: decoding $ihe-pix Parameters: %w", err)
+	}
+
+	identifiers := make([]wellally.Identifier, 0, len(out.Parameter))
+	for _, p := range out.Parameter {
+		if p.Name != "targetIdentifier" {
+			continue
+		}
+		if p.ValueIdentifier.System == "" || p.ValueIdentifier.Value == "" {
+			continue
+		}
+		identifiers = append(identifiers, wellally.Identifier{System: p.ValueIdentifier.System, Value: p.ValueIdentifier.Value})
+	}
+	return identifiers, nil
+}
@@ -0,0 +1,102 @@
+package pdq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// InMemoryResolver is a Resolver backed by an in-process fixture,
+// useful for tests that exercise PDQm/PIXm consumers without a FHIR
+// server.
+type InMemoryResolver struct {
+	// People is searched by Resolve.
+	People []wellally.Person
+	// CrossReferences maps "system|value" to every Identifier known to
+	// refer to the same patient, across assigning systems.
+	CrossReferences map[string][]wellally.Identifier
+}
+
+// Resolve returns every Person in People matching query. An empty query
+// field is treated as a wildcard for that field.
+func (r *InMemoryResolver) Resolve(_ context.Context, query PDQQuery) ([]wellally.Person, error) {
+	var matches []wellally.Person
+	for _, person := range r.People {
+		if !matchesQuery(person, query) {
+			continue
+		}
+		matches = append(matches, person)
+	}
+	return matches, nil
+}
+
+func matchesQuery(person wellally.Person, query PDQQuery) bool {
+	if query.Identifier != nil && !hasIdentifier(person.Identifier, *query.Identifier) {
+		return false
+	}
+	if query.FamilyName != "" && !hasFamilyName(person.Name, query.FamilyName) {
+		return false
+	}
+	if query.GivenName != "" && !hasGivenName(person.Name, query.GivenName) {
+		return false
+	}
+	if query.Gender != nil && (person.Gender == nil || *person.Gender != *query.Gender) {
+		return false
+	}
+	if query.BirthDate != "" && person.BirthDate.Format("2006-01-02") != query.BirthDate {
+		return false
+	}
+	return true
+}
+
+func hasIdentifier(identifiers []wellally.Identifier, want wellally.Identifier) bool {
+	for _, id := range identifiers {
+		if id.System == want.System && id.Value == want.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFamilyName(names []wellally.HumanName, family string) bool {
+	for _, name := range names {
+		if strings.EqualFold(name.Family, family) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasGivenName(names []wellally.HumanName, given string) bool {
+	for _, name := range names {
+		for _, g := range name.Given {
+			if strings.EqualFold(g, given) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CrossReference looks up id in CrossReferences and returns only the
+// entries whose System is in targetSystems.
+func (r *InMemoryResolver) CrossReference(_ context.Context, id wellally.Identifier, targetSystems []string) ([]wellally.Identifier, error) {
+	key := id.System + "|" + id.Value
+	known, ok := r.CrossReferences[key]
+	if !ok {
+		return nil, fmt.Errorf("pdq: no cross-references known for %s", key)
+	}
+	wanted := make(map[string]bool, len(targetSystems))
+	for _, system := range targetSystems {
+		wanted[system] = true
+	}
+	filtered := make([]wellally.Identifier, 0, len(known))
+	for _, candidate := range known {
+		if wanted[candidate.System] {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered, nil
+}
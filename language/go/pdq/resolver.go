@@ -0,0 +1,36 @@
+package pdq
+
+import (
+	"context"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// PDQQuery carries the demographic search parameters a PDQm consumer
+// sends to locate candidate patients. At least one field should be set;
+// resolvers are free to reject an empty query.
+type PDQQuery struct {
+	// Identifier restricts the search to a known assigning system/value,
+	// e.g. a regional MPI identifier.
+	Identifier *wellally.Identifier
+	// FamilyName is matched against HumanName.Family.
+	FamilyName string
+	// GivenName is matched against any entry in HumanName.Given.
+	GivenName string
+	// BirthDate, formatted as YYYY-MM-DD, narrows the search further.
+	BirthDate string
+	// Gender narrows the search further.
+	Gender *wellally.Gender
+}
+
+// Resolver is an IHE PIXm/PDQm consumer client: Resolve performs a
+// demographics-based PDQm query, CrossReference performs a PIXm
+// ($ihe-pix) identifier cross-reference.
+type Resolver interface {
+	// Resolve returns the Person records matching query.
+	Resolve(ctx context.Context, query PDQQuery) ([]wellally.Person, error)
+	// CrossReference returns the Identifiers known for id under each of
+	// targetSystems (assigning-authority URIs), reconciling id across
+	// facilities the way IHE PIXm's $ihe-pix operation does.
+	CrossReference(ctx context.Context, id wellally.Identifier, targetSystems []string) ([]wellally.Identifier, error)
+}
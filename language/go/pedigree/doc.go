@@ -0,0 +1,31 @@
+// Package pedigree analyzes a wellally.FamilyHealthTree as a relationship
+// graph and scores hereditary disease risk from it.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+//
+// FamilyMember only records each member's relation to the proband, not
+// arbitrary pairwise edges or which side of the family a relative is on,
+// so BuildGraph reconstructs a parent/child pedigree DAG by introducing
+// unexported synthetic ancestor nodes for whichever real relatives a
+// relation label implies but doesn't name — e.g. a reported "aunt" shares
+// two unnamed grandparents with the proband's unnamed parent, and
+// BuildGraph creates both synthetic nodes so that relationship, not just
+// the aunt, is represented. Mother/Father members are used directly as
+// those parent slots when present, so a reported sibling and a reported
+// mother correctly share an ancestor while a reported mother and father
+// do not. DegreeOfRelationship and CoefficientOfRelationship then walk
+// every common ancestor of two members and sum r = Σ (1/2)^L over each
+// distinct path, per Wright's path-counting method — e.g. full siblings
+// share two parents, each contributing a 2-edge path, giving r = 0.5.
+//
+// Analyze layers a simplified Elston-Stewart-style Bayesian update on top
+// of that graph: each affected relative's carrier probability is derived
+// from their coefficient of relationship to the proband under Mendelian
+// transmission, converted to a likelihood ratio, and relatives are
+// combined by multiplying likelihood ratios in odds space. This
+// conditions only on the proband's genotype rather than peeling every
+// family member's jointly, which is the right simplification for a
+// screening signal but is documented here so callers don't mistake it
+// for a full peel.
+package pedigree
@@ -0,0 +1,228 @@
+package pedigree
+
+import (
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// Graph is FamilyHealthTree reconstructed as a parent/child pedigree DAG,
+// so relationship queries between any two members share the ancestors
+// their relation labels imply.
+type Graph struct {
+	ProbandID string
+	parents   map[string][]string
+	known     map[string]bool
+}
+
+// BuildGraph reconstructs Graph from t.
+//
+// FamilyMember only records each member's relation to the proband, not
+// arbitrary pairwise edges or which side of the family a relative is on,
+// so BuildGraph introduces unexported synthetic ancestor nodes standing
+// in for whichever real relatives a relation label implies but doesn't
+// name — e.g. a reported "aunt" shares two unnamed grandparents with the
+// proband's unnamed parent, not with the proband directly, and BuildGraph
+// creates both synthetic nodes the same way a pedigree chart would.
+// Mother/Father members are used directly as those parent slots when
+// present, so a reported sibling and a reported mother correctly share an
+// ancestor while a reported mother and father do not. RelationOther has
+// no well-defined structure and is left unconnected; queries involving it
+// return an error.
+func BuildGraph(t wellally.FamilyHealthTree) *Graph {
+	g := &Graph{ProbandID: t.ProbandID, parents: make(map[string][]string), known: map[string]bool{t.ProbandID: true}}
+
+	motherID, fatherID := "", ""
+	for _, member := range t.Members {
+		switch member.RelationToProband {
+		case wellally.RelationMother:
+			motherID = member.ID
+		case wellally.RelationFather:
+			fatherID = member.ID
+		}
+	}
+	if motherID == "" {
+		motherID = g.synth(t.ProbandID, "mother")
+	}
+	if fatherID == "" {
+		fatherID = g.synth(t.ProbandID, "father")
+	}
+	g.addParent(t.ProbandID, motherID)
+	g.addParent(t.ProbandID, fatherID)
+
+	for _, member := range t.Members {
+		if member.ID == t.ProbandID || member.RelationToProband == wellally.RelationSelf {
+			continue
+		}
+		switch member.RelationToProband {
+		case wellally.RelationMother, wellally.RelationFather:
+			// Already wired in as one of the proband's two parent slots above.
+			g.known[member.ID] = true
+		case wellally.RelationChild:
+			g.addParent(member.ID, t.ProbandID)
+			g.known[member.ID] = true
+		case wellally.RelationSibling:
+			g.addParent(member.ID, motherID)
+			g.addParent(member.ID, fatherID)
+			g.known[member.ID] = true
+		case wellally.RelationGrandparent:
+			link := g.synth(member.ID, "parent")
+			g.addParent(t.ProbandID, link)
+			g.addParent(link, member.ID)
+			g.known[member.ID] = true
+		case wellally.RelationGrandchild:
+			link := g.synth(member.ID, "child")
+			g.addParent(link, t.ProbandID)
+			g.addParent(member.ID, link)
+			g.known[member.ID] = true
+		case wellally.RelationAunt, wellally.RelationUncle:
+			parentLink := g.synth(member.ID, "parent")
+			grandparentA := g.synth(member.ID, "grandparent-a")
+			grandparentB := g.synth(member.ID, "grandparent-b")
+			g.addParent(t.ProbandID, parentLink)
+			g.addParent(parentLink, grandparentA)
+			g.addParent(parentLink, grandparentB)
+			g.addParent(member.ID, grandparentA)
+			g.addParent(member.ID, grandparentB)
+			g.known[member.ID] = true
+		case wellally.RelationCousin:
+			parentLink := g.synth(member.ID, "parent")
+			grandparentA := g.synth(member.ID, "grandparent-a")
+			grandparentB := g.synth(member.ID, "grandparent-b")
+			cousinParentLink := g.synth(member.ID, "cousin-parent")
+			g.addParent(t.ProbandID, parentLink)
+			g.addParent(parentLink, grandparentA)
+			g.addParent(parentLink, grandparentB)
+			g.addParent(cousinParentLink, grandparentA)
+			g.addParent(cousinParentLink, grandparentB)
+			g.addParent(member.ID, cousinParentLink)
+			g.known[member.ID] = true
+		}
+	}
+	return g
+}
+
+// synth returns a unique synthetic ancestor node id standing in for an
+// unnamed relative implied by memberID's relation label.
+func (g *Graph) synth(memberID, tag string) string {
+	return "~" + memberID + ":" + tag
+}
+
+// addParent records that parent is one of child's parents, ignoring a
+// duplicate edge.
+func (g *Graph) addParent(child, parent string) {
+	for _, existing := range g.parents[child] {
+		if existing == parent {
+			return
+		}
+	}
+	g.parents[child] = append(g.parents[child], parent)
+}
+
+// ancestorDistances returns every ancestor reachable upward from id
+// (including id itself, at distance 0) mapped to the edge-length of each
+// distinct path to it. A node normally reaches a given ancestor via a
+// single path in the DAGs BuildGraph constructs, but this keeps every
+// path rather than just the shortest so CoefficientOfRelationship can sum
+// them correctly if that ever changes.
+func (g *Graph) ancestorDistances(id string) map[string][]int {
+	distances := map[string][]int{id: {0}}
+	var walk func(node string, depth int)
+	walk = func(node string, depth int) {
+		for _, parent := range g.parents[node] {
+			distances[parent] = append(distances[parent], depth+1)
+			walk(parent, depth+1)
+		}
+	}
+	walk(id, 0)
+	return distances
+}
+
+// DegreeOfRelationship returns the number of meioses on the shortest
+// common-ancestor path between aID and bID. It errors both when either
+// member isn't placeable in the tree (e.g. RelationOther) and when both
+// are placeable but share no common ancestor (e.g. the proband's mother
+// and father), since neither case has a meaningful meiotic distance.
+func (g *Graph) DegreeOfRelationship(aID, bID string) (int, error) {
+	if aID == bID {
+		return 0, nil
+	}
+	if !g.known[aID] || !g.known[bID] {
+		return 0, fmt.Errorf("pedigree: no known relationship path between %q and %q", aID, bID)
+	}
+	ancestorsA := g.ancestorDistances(aID)
+	ancestorsB := g.ancestorDistances(bID)
+	best := -1
+	for ancestor, distancesA := range ancestorsA {
+		distancesB, ok := ancestorsB[ancestor]
+		if !ok {
+			continue
+		}
+		for _, da := range distancesA {
+			for _, db := range distancesB {
+				if best == -1 || da+db < best {
+					best = da + db
+				}
+			}
+		}
+	}
+	if best == -1 {
+		return 0, fmt.Errorf("pedigree: no known relationship path between %q and %q", aID, bID)
+	}
+	return best, nil
+}
+
+// CoefficientOfRelationship returns r = Σ (1/2)^L summed over every
+// distinct common-ancestor path connecting aID and bID, per Wright's
+// path-counting method — e.g. full siblings share two parents, each
+// contributing a 2-edge path, giving r = (1/2)^2 + (1/2)^2 = 0.5. Two
+// members who are both placeable in the tree but share no common
+// ancestor (e.g. the proband's mother and father) are unrelated founders
+// and correctly get r = 0, not an error; an error is reserved for a
+// member that isn't placeable in the tree at all (e.g. RelationOther).
+func (g *Graph) CoefficientOfRelationship(aID, bID string) (float64, error) {
+	if aID == bID {
+		return 1, nil
+	}
+	if !g.known[aID] || !g.known[bID] {
+		return 0, fmt.Errorf("pedigree: no known relationship path between %q and %q", aID, bID)
+	}
+	ancestorsA := g.ancestorDistances(aID)
+	ancestorsB := g.ancestorDistances(bID)
+	common := make(map[string]bool)
+	for ancestor := range ancestorsA {
+		if _, ok := ancestorsB[ancestor]; ok {
+			common[ancestor] = true
+		}
+	}
+	r := 0.0
+	for ancestor := range common {
+		// A common ancestor above another common ancestor is only reached
+		// through that one on both sides, which is not a Wright path.
+		above := false
+		for other := range common {
+			if _, ok := g.ancestorDistances(other)[ancestor]; ok && other != ancestor {
+				above = true
+				break
+			}
+		}
+		if above {
+			continue
+		}
+		for _, da := range ancestorsA[ancestor] {
+			for _, db := range ancestorsB[ancestor] {
+				r += halvedPower(da + db)
+			}
+		}
+	}
+	return r, nil
+}
+
+// halvedPower returns (1/2)^exp.
+func halvedPower(exp int) float64 {
+	r := 1.0
+	for i := 0; i < exp; i++ {
+		r /= 2
+	}
+	return r
+}
@@ -0,0 +1,196 @@
+package pedigree
+
+import (
+	"math"
+	"testing"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+const float64EpsilonForTests = 1e-9
+
+func snomedCondition(code string) wellally.CodeableConcept {
+	return wellally.CodeableConcept{Coding: []wellally.Coding{{System: "http://snomed.info/sct", Code: code}}}
+}
+
+func boolPtr(b bool) *bool                { return &b }
+func sexPtr(s wellally.Sex) *wellally.Sex { return &s }
+
+// TestCoefficientOfRelationship pins r for the textbook relationships the
+// request calls out: full sibs r=1/2, grandparent/grandchild r=1/4, plus
+// self (r=1) and unrelated founders (r=0) as boundary cases.
+func TestCoefficientOfRelationship(t *testing.T) {
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "mother", RelationToProband: wellally.RelationMother},
+			{ID: "father", RelationToProband: wellally.RelationFather},
+			{ID: "sibling", RelationToProband: wellally.RelationSibling},
+			{ID: "grandparent", RelationToProband: wellally.RelationGrandparent},
+		},
+	}
+	graph := BuildGraph(tree)
+
+	cases := []struct {
+		name    string
+		a, b    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "self", a: "proband", b: "proband", want: 1},
+		{name: "full siblings", a: "sibling", b: "proband", want: 0.5},
+		{name: "grandparent", a: "grandparent", b: "proband", want: 0.25},
+		{name: "unrelated founders", a: "mother", b: "father", want: 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := graph.CoefficientOfRelationship(tc.a, tc.b)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CoefficientOfRelationship(%q, %q): err = %v, wantErr %v", tc.a, tc.b, err, tc.wantErr)
+			}
+			if math.Abs(got-tc.want) > float64EpsilonForTests {
+				t.Errorf("CoefficientOfRelationship(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestDegreeOfRelationshipUnplaceableMember checks that a RelationOther
+// member, which BuildGraph deliberately leaves unconnected, errors rather
+// than reporting a meaningless distance.
+func TestDegreeOfRelationshipUnplaceableMember(t *testing.T) {
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "other", RelationToProband: wellally.RelationOther},
+		},
+	}
+	graph := BuildGraph(tree)
+	if _, err := graph.DegreeOfRelationship("other", "proband"); err == nil {
+		t.Error("DegreeOfRelationship(other, proband): want error for an unplaceable RelationOther member, got nil")
+	}
+}
+
+// TestAnalyzeNoAffectedRelativesShortCircuits checks that Analyze returns
+// the bare prior, with no contributing relatives, when nobody in the tree
+// is reported with cond.
+func TestAnalyzeNoAffectedRelativesShortCircuits(t *testing.T) {
+	cond := snomedCondition("38341003") // hypertension
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling},
+		},
+	}
+	report := Analyze(tree, cond, AutosomalDominant, 1)
+	if report.ContributingRelatives != nil {
+		t.Errorf("ContributingRelatives = %v, want nil", report.ContributingRelatives)
+	}
+	if report.Posterior != report.Prior {
+		t.Errorf("Posterior = %v, want it to equal Prior (%v) when nothing affected was found", report.Posterior, report.Prior)
+	}
+}
+
+// TestAnalyzeWorkedExample pins Analyze's posterior against a hand-worked
+// calculation: a single full sibling affected under a fully-penetrant
+// autosomal-dominant model, r=0.5, population allele frequency 0.01.
+//
+//	prior = 1-(1-q)^2 = 0.0199
+//	carrier|proband-carrier = r+(1-r)q = 0.505, carrier|non-carrier = (1-r)q = 0.005
+//	likelihood ratio = 0.505/0.005 = 101
+//	posterior = (prior/(1-prior)*101) / (1+prior/(1-prior)*101) ≈ 0.6722073578595321
+func TestAnalyzeWorkedExample(t *testing.T) {
+	cond := snomedCondition("38341003")
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling, Conditions: []wellally.CodeableConcept{cond}},
+		},
+	}
+	report := Analyze(tree, cond, AutosomalDominant, 1)
+
+	const wantPrior = 0.0199
+	const wantPosterior = 0.6722073578595321
+	if math.Abs(report.Prior-wantPrior) > 1e-6 {
+		t.Errorf("Prior = %v, want %v", report.Prior, wantPrior)
+	}
+	if math.Abs(report.Posterior-wantPosterior) > 1e-9 {
+		t.Errorf("Posterior = %v, want %v", report.Posterior, wantPosterior)
+	}
+	if len(report.ContributingRelatives) != 1 || report.ContributingRelatives[0] != "sibling" {
+		t.Errorf("ContributingRelatives = %v, want [sibling]", report.ContributingRelatives)
+	}
+}
+
+// TestAnalyzeDeceasedRelativeIncluded checks that a deceased affected
+// relative still contributes to the posterior — death doesn't erase
+// genotype, so Analyze must not filter on Deceased.
+func TestAnalyzeDeceasedRelativeIncluded(t *testing.T) {
+	cond := snomedCondition("38341003")
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling, Deceased: boolPtr(true), Conditions: []wellally.CodeableConcept{cond}},
+		},
+	}
+	report := Analyze(tree, cond, AutosomalDominant, 1)
+
+	const wantPosterior = 0.6722073578595321
+	if math.Abs(report.Posterior-wantPosterior) > 1e-9 {
+		t.Errorf("Posterior = %v, want %v (deceased relatives must still be weighed)", report.Posterior, wantPosterior)
+	}
+	if len(report.ContributingRelatives) != 1 || report.ContributingRelatives[0] != "sibling" {
+		t.Errorf("ContributingRelatives = %v, want [sibling]", report.ContributingRelatives)
+	}
+}
+
+// TestAnalyzeXLinkedUnknownSexMarginalizes pins Analyze's X-linked
+// posterior when the affected relative's sex is unknown, which must
+// average the male and female probabilities of being affected rather
+// than pick one:
+// r=0.5, population allele frequency 0.01, penetrance 0.8.
+func TestAnalyzeXLinkedUnknownSexMarginalizes(t *testing.T) {
+	cond := snomedCondition("312894000") // hemophilia A
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling, Conditions: []wellally.CodeableConcept{cond}},
+		},
+	}
+	report := Analyze(tree, cond, XLinked, 0.8)
+
+	const wantPrior = 0.01495
+	const wantPosterior = 0.6965546598415303
+	if math.Abs(report.Prior-wantPrior) > 1e-6 {
+		t.Errorf("Prior = %v, want %v", report.Prior, wantPrior)
+	}
+	if math.Abs(report.Posterior-wantPosterior) > 1e-9 {
+		t.Errorf("Posterior = %v, want %v", report.Posterior, wantPosterior)
+	}
+}
+
+// TestAnalyzeXLinkedKnownSexDiffersFromUnknown checks that marginalizing
+// over unknown sex actually changes the result versus a known-sex relative
+// with the same coefficient of relationship — otherwise the averaging in
+// TestAnalyzeXLinkedUnknownSexMarginalizes could pass by coincidence.
+func TestAnalyzeXLinkedKnownSexDiffersFromUnknown(t *testing.T) {
+	cond := snomedCondition("312894000")
+	knownSexTree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling, Sex: sexPtr(wellally.SexMale), Conditions: []wellally.CodeableConcept{cond}},
+		},
+	}
+	unknownSexTree := wellally.FamilyHealthTree{
+		ProbandID: "proband",
+		Members: []wellally.FamilyMember{
+			{ID: "sibling", RelationToProband: wellally.RelationSibling, Conditions: []wellally.CodeableConcept{cond}},
+		},
+	}
+
+	knownReport := Analyze(knownSexTree, cond, XLinked, 0.8)
+	unknownReport := Analyze(unknownSexTree, cond, XLinked, 0.8)
+	if math.Abs(knownReport.Posterior-unknownReport.Posterior) < 1e-9 {
+		t.Errorf("known-sex posterior (%v) should differ from unknown-sex marginalized posterior (%v)", knownReport.Posterior, unknownReport.Posterior)
+	}
+}
@@ -0,0 +1,184 @@
+package pedigree
+
+import wellally "github.com/huifer/wellally-schemas/language/go"
+
+// InheritanceModel selects which Mendelian pattern Analyze scores
+// against.
+type InheritanceModel string
+
+const (
+	AutosomalDominant  InheritanceModel = "autosomal-dominant"
+	AutosomalRecessive InheritanceModel = "autosomal-recessive"
+	XLinked            InheritanceModel = "x-linked"
+)
+
+// populationAlleleFrequency is the assumed population frequency of the
+// risk allele behind cond, used to anchor Prior and relatives' carrier
+// probabilities under Hardy-Weinberg equilibrium. It is a deliberately
+// generic placeholder rather than a locus-specific estimate; callers who
+// know the real population frequency for their condition should rescale
+// Prior/Posterior accordingly.
+const populationAlleleFrequency = 0.01
+
+// RiskReport is the outcome of Analyze: how likely the proband is to
+// carry the allele behind cond, before and after weighing relatives'
+// reported Conditions.
+type RiskReport struct {
+	Prior                 float64
+	Posterior             float64
+	ContributingRelatives []string
+}
+
+// Analyze estimates the proband's posterior probability of carrying the
+// allele behind cond, given which relatives in t are reported with it.
+//
+// It is a simplified Elston-Stewart-style Bayesian update that conditions
+// on a single hypothesis, the proband's carrier status, rather than
+// peeling the full joint genotype likelihood of every family member: for
+// each affected relative, their CoefficientOfRelationship r to the
+// proband gives the probability a Mendelian transmission carried the
+// proband's allele to them (r + (1-r)*populationAlleleFrequency if the
+// proband is a carrier, (1-r)*populationAlleleFrequency if not), which
+// combines with penetrance into a likelihood ratio for the proband being
+// a carrier. Relatives are combined by multiplying likelihood ratios in
+// odds space — exact when relatives don't share genotype information
+// with each other beyond what they each share with the proband, which is
+// the right simplification for a screening signal rather than a
+// definitive genotype likelihood.
+//
+// Deceased relatives are still included (death doesn't erase genotype).
+// Members with unknown sex are marginalized for the X-linked model by
+// averaging the male and female probabilities of being affected, under
+// each hypothesis, before taking the likelihood ratio. Analyze short-circuits
+// to the prior when no relative in t is reported with cond.
+func Analyze(t wellally.FamilyHealthTree, cond wellally.CodeableConcept, model InheritanceModel, penetrance float64) RiskReport {
+	graph := BuildGraph(t)
+	prior := priorForModel(model)
+
+	var affected []wellally.FamilyMember
+	for _, member := range t.Members {
+		if member.ID == t.ProbandID {
+			continue
+		}
+		if hasCondition(member.Conditions, cond) {
+			affected = append(affected, member)
+		}
+	}
+	if len(affected) == 0 {
+		return RiskReport{Prior: prior, Posterior: prior}
+	}
+
+	odds := prior / (1 - prior)
+	contributing := make([]string, 0, len(affected))
+	for _, member := range affected {
+		r, err := graph.CoefficientOfRelationship(member.ID, t.ProbandID)
+		if err != nil {
+			continue // RelationOther or otherwise unplaceable in the tree
+		}
+		odds *= likelihoodRatio(model, member.Sex, r, clamp01(penetrance))
+		contributing = append(contributing, member.ID)
+	}
+	if len(contributing) == 0 {
+		return RiskReport{Prior: prior, Posterior: prior}
+	}
+
+	posterior := odds / (1 + odds)
+	return RiskReport{
+		Prior:                 prior,
+		Posterior:             clamp01(posterior),
+		ContributingRelatives: contributing,
+	}
+}
+
+// priorForModel is the population-level probability a proband of unknown
+// family history carries the genotype that causes disease under model,
+// derived from populationAlleleFrequency under Hardy-Weinberg
+// equilibrium.
+func priorForModel(model InheritanceModel) float64 {
+	q := populationAlleleFrequency
+	switch model {
+	case AutosomalRecessive:
+		return q * q
+	case XLinked:
+		// Marginalized over sex: hemizygous males (q) and homozygous or
+		// heterozygous females (1-(1-q)^2), weighted evenly.
+		male := q
+		female := 1 - (1-q)*(1-q)
+		return (male + female) / 2
+	default: // AutosomalDominant
+		return 1 - (1-q)*(1-q)
+	}
+}
+
+// likelihoodRatio is P(relative affected | proband carrier) /
+// P(relative affected | proband non-carrier) for a relative with
+// coefficient of relationship r to the proband, under model.
+func likelihoodRatio(model InheritanceModel, sex *wellally.Sex, r, penetrance float64) float64 {
+	q := populationAlleleFrequency
+	carrierGivenProbandCarrier := r + (1-r)*q
+	carrierGivenProbandNonCarrier := (1 - r) * q
+
+	affectedGivenCarrierProb := func(carrierProb float64) float64 {
+		switch model {
+		case AutosomalRecessive:
+			return carrierProb * carrierProb * penetrance
+		case XLinked:
+			male := carrierProb * penetrance
+			female := carrierProb * carrierProb * penetrance
+			switch {
+			case sex == nil:
+				return (male + female) / 2
+			case *sex == wellally.SexMale:
+				return male
+			default:
+				return female
+			}
+		default: // AutosomalDominant
+			return carrierProb * penetrance
+		}
+	}
+
+	numerator := affectedGivenCarrierProb(carrierGivenProbandCarrier)
+	denominator := affectedGivenCarrierProb(carrierGivenProbandNonCarrier)
+	if denominator == 0 {
+		if numerator == 0 {
+			return 1
+		}
+		denominator = 1e-12
+	}
+	return numerator / denominator
+}
+
+func hasCondition(conditions []wellally.CodeableConcept, want wellally.CodeableConcept) bool {
+	for _, c := range conditions {
+		if conditionMatches(c, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionMatches(a, b wellally.CodeableConcept) bool {
+	for _, ca := range a.Coding {
+		for _, cb := range b.Coding {
+			if ca.System == cb.System && ca.Code == cb.Code {
+				return true
+			}
+		}
+	}
+	if a.Text != nil && b.Text != nil {
+		return *a.Text == *b.Text
+	}
+	return false
+}
+
+func clamp01(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	default:
+		return v
+	}
+}
@@ -0,0 +1,130 @@
+package terminology
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BindingIssue reports one Binding whose constraint was violated.
+type BindingIssue struct {
+	Path     string
+	System   string
+	Strength BindingStrength
+	Message  string
+}
+
+func (i BindingIssue) String() string {
+	return fmt.Sprintf("%s [%s]: %s", i.Path, i.Strength, i.Message)
+}
+
+// Bind marshals v to JSON and checks every Binding in r against it,
+// returning one BindingIssue per violation found. A Binding whose Path
+// matches nothing in v (e.g. an optional field that was omitted) is
+// silently skipped.
+func (r *Registry) Bind(v any) []BindingIssue {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []BindingIssue{{Message: fmt.Sprintf("terminology: marshaling %T: %v", v, err)}}
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []BindingIssue{{Message: fmt.Sprintf("terminology: re-parsing %T: %v", v, err)}}
+	}
+
+	var issues []BindingIssue
+	for _, binding := range r.Bindings {
+		segments := strings.Split(strings.Trim(binding.Path, "/"), "/")
+		for _, match := range collectMatches(doc, segments, "") {
+			if issue, ok := checkBinding(binding, match); ok {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// match is one node found while resolving a Binding's Path.
+type match struct {
+	path  string
+	value any
+}
+
+// collectMatches resolves segments against node, expanding "*" over
+// every element of an array it finds there.
+func collectMatches(node any, segments []string, path string) []match {
+	if len(segments) == 0 {
+		return []match{{path: path, value: node}}
+	}
+	segment, rest := segments[0], segments[1:]
+
+	if segment == "*" {
+		arr, ok := node.([]any)
+		if !ok {
+			return nil
+		}
+		var matches []match
+		for i, item := range arr {
+			matches = append(matches, collectMatches(item, rest, fmt.Sprintf("%s/%d", path, i))...)
+		}
+		return matches
+	}
+
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	child, present := obj[segment]
+	if !present {
+		return nil
+	}
+	return collectMatches(child, rest, path+"/"+segment)
+}
+
+func checkBinding(binding Binding, m match) (BindingIssue, bool) {
+	if binding.Validator != nil {
+		code, ok := m.value.(string)
+		if !ok {
+			return BindingIssue{}, false
+		}
+		if err := binding.Validator.Validate(code); err != nil {
+			return BindingIssue{Path: m.path, Strength: binding.Strength, Message: err.Error()}, true
+		}
+		return BindingIssue{}, false
+	}
+	return checkCodeableConcept(binding, m)
+}
+
+// checkCodeableConcept checks a CodeableConcept- or Coding-shaped node's
+// system(s) against binding.System.
+func checkCodeableConcept(binding Binding, m match) (BindingIssue, bool) {
+	obj, ok := m.value.(map[string]any)
+	if !ok {
+		return BindingIssue{}, false
+	}
+
+	codings, hasCodingArray := obj["coding"].([]any)
+	if !hasCodingArray {
+		if _, isCoding := obj["code"]; isCoding {
+			codings = []any{obj}
+		}
+	}
+	if len(codings) == 0 {
+		return BindingIssue{Path: m.path, System: binding.System, Strength: binding.Strength, Message: "no coding present"}, true
+	}
+	for _, c := range codings {
+		coding, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if system, _ := coding["system"].(string); system == binding.System {
+			return BindingIssue{}, false
+		}
+	}
+	return BindingIssue{
+		Path:     m.path,
+		System:   binding.System,
+		Strength: binding.Strength,
+		Message:  fmt.Sprintf("no coding uses the bound system %q", binding.System),
+	}, true
+}
@@ -0,0 +1,35 @@
+package terminology
+
+import (
+	"testing"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// TestBindFlagsWrongSystemConditions checks that DefaultRegistry's
+// condition bindings actually reach Person.ClinicalSummary.Conditions and
+// FamilyMember.Conditions — both previously unreachable behind a single
+// "/conditions/*" binding that matched neither nested path.
+func TestBindFlagsWrongSystemConditions(t *testing.T) {
+	icd10 := wellally.CodeableConcept{Coding: []wellally.Coding{{System: "http://hl7.org/fhir/sid/icd-10", Code: "I10"}}}
+
+	person := wellally.Person{
+		ID: "person-1",
+		ClinicalSummary: &wellally.ClinicalSummary{
+			Conditions: []wellally.CodeableConcept{icd10},
+		},
+	}
+	if issues := Bind(person); len(issues) == 0 {
+		t.Error("Bind(Person): want an issue for a non-SNOMED clinicalSummary condition, got none")
+	}
+
+	tree := wellally.FamilyHealthTree{
+		ProbandID: "person-1",
+		Members: []wellally.FamilyMember{
+			{ID: "member-1", RelationToProband: wellally.RelationMother, Conditions: []wellally.CodeableConcept{icd10}},
+		},
+	}
+	if issues := Bind(tree); len(issues) == 0 {
+		t.Error("Bind(FamilyHealthTree): want an issue for a non-SNOMED member condition, got none")
+	}
+}
@@ -0,0 +1,66 @@
+package terminology
+
+// BindingStrength mirrors FHIR's ValueSet binding strength: how strongly
+// a Binding's constraint should be enforced.
+type BindingStrength string
+
+const (
+	// StrengthRequired means the bound code system must be used exactly.
+	StrengthRequired BindingStrength = "required"
+	// StrengthPreferred means the bound code system is encouraged but a
+	// mismatch is only reported, not treated as fatal by callers.
+	StrengthPreferred BindingStrength = "preferred"
+	// StrengthExample means the bound code system is illustrative only.
+	StrengthExample BindingStrength = "example"
+)
+
+// Binding constrains the value found at Path, modeled after how FHIR
+// profiles constrain Coding.system/Coding.code.
+type Binding struct {
+	// Path is a JSON pointer into the value being checked, with "*"
+	// standing in for any array index, e.g. "/results/*/code".
+	Path string
+	// System is the expected terminology system URI. It is checked
+	// against every coding[].system found at Path when Validator is nil.
+	System string
+	// Strength records how strictly System/Validator should be enforced.
+	Strength BindingStrength
+	// Validator, when set, validates the scalar string found at Path
+	// directly (used for UCUM units and BCP-47 language tags, which
+	// aren't expressed as CodeableConcept/Coding).
+	Validator CodeSystemValidator
+}
+
+// Registry is an ordered set of Bindings, checked independently against
+// the same document.
+type Registry struct {
+	Bindings []Binding
+}
+
+// NewRegistry builds a Registry from the given bindings.
+func NewRegistry(bindings ...Binding) *Registry {
+	return &Registry{Bindings: bindings}
+}
+
+// DefaultRegistry carries the bindings implied by the wellally package's
+// own doc comments: LOINC on lab results, SNOMED CT on imaging body sites
+// and conditions (both a Person's clinical summary and a family member's),
+// RxNorm on medications, UCUM on dosage units and on lab result value and
+// reference range quantity units, and BCP-47 on language preferences.
+var DefaultRegistry = NewRegistry(
+	Binding{Path: "/results/*/code", System: "http://loinc.org", Strength: StrengthRequired},
+	Binding{Path: "/bodySite", System: "http://snomed.info/sct", Strength: StrengthRequired},
+	Binding{Path: "/clinicalSummary/conditions/*", System: "http://snomed.info/sct", Strength: StrengthPreferred},
+	Binding{Path: "/members/*/conditions/*", System: "http://snomed.info/sct", Strength: StrengthPreferred},
+	Binding{Path: "/medication", System: "http://www.nlm.nih.gov/research/umls/rxnorm", Strength: StrengthRequired},
+	Binding{Path: "/dosage/unit", Strength: StrengthRequired, Validator: UCUMValidator{}},
+	Binding{Path: "/results/*/value/unit", Strength: StrengthRequired, Validator: UCUMValidator{}},
+	Binding{Path: "/results/*/referenceRange/low/unit", Strength: StrengthRequired, Validator: UCUMValidator{}},
+	Binding{Path: "/results/*/referenceRange/high/unit", Strength: StrengthRequired, Validator: UCUMValidator{}},
+	Binding{Path: "/language/*", Strength: StrengthRequired, Validator: BCP47Validator{}},
+)
+
+// Bind checks v against DefaultRegistry.
+func Bind(v any) []BindingIssue {
+	return DefaultRegistry.Bind(v)
+}
@@ -0,0 +1,18 @@
+// Package terminology enforces the terminology bindings implied by the
+// wellally field documentation (LOINC on LabResult.Code, SNOMED CT on
+// BodySite and conditions, RxNorm on MedicationRecord.Medication, UCUM on
+// Quantity.Unit, and IETF BCP-47 on Person.Language) but never checked at
+// runtime.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+//
+// A Binding pairs a JSON-pointer path (with "*" wildcard segments for
+// array elements, mirroring how FHIR profiles constrain Coding.system and
+// Coding.code) with either an expected terminology system URI or a
+// pluggable CodeSystemValidator for code systems that need real grammar
+// checking (UCUM, BCP-47). Bind walks a value's JSON representation
+// against a Registry of Bindings and reports every violation found.
+// DefaultRegistry carries the bindings implied by the wellally doc
+// comments; Bind(v) is a shorthand for DefaultRegistry.Bind(v).
+package terminology
@@ -0,0 +1,112 @@
+package terminology
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CodeSystemValidator validates a single code value against a
+// terminology system, without needing network access.
+type CodeSystemValidator interface {
+	Validate(code string) error
+}
+
+// ucumAtoms is a curated subset of the UCUM base and derived unit atoms
+// wellally.Quantity values are expected to use. It is intentionally not
+// exhaustive; it covers the clinical units (mass, volume, time,
+// concentration) this module's domain types actually carry.
+var ucumAtoms = map[string]bool{
+	"m": true, "g": true, "s": true, "l": true, "L": true, "mol": true, "cd": true, "K": true,
+	"rad": true, "sr": true, "Hz": true, "N": true, "Pa": true, "J": true, "W": true, "A": true,
+	"V": true, "Cel": true, "cal": true, "U": true, "eq": true, "osm": true, "kat": true,
+	"%": true, "[iU]": true, "[IU]": true, "10*3": true, "10*6": true, "10*9": true, "10*12": true,
+	"min": true, "h": true, "d": true, "wk": true, "mo": true,
+}
+
+var ucumPrefixes = []string{
+	"da", "h", "k", "M", "G", "T", "P", "E", "Z", "Y",
+	"d", "c", "m", "u", "n", "p", "f", "a", "z", "y",
+}
+
+// UCUMValidator offline-validates UCUM unit expressions (e.g. "mg",
+// "mg/dL", "mmol/L") using an embedded grammar of atoms and prefixes,
+// without querying a UCUM service.
+type UCUMValidator struct{}
+
+// Validate reports an error if code is not a UCUM expression built from
+// known atoms, optionally combined with '.', '/', and integer exponents.
+func (UCUMValidator) Validate(code string) error {
+	if code == "" {
+		return fmt.Errorf("terminology: empty UCUM unit")
+	}
+	if code == "1" {
+		return nil // the dimensionless unit
+	}
+	for _, term := range splitUCUM(code) {
+		if ucumAtoms[term] {
+			continue
+		}
+		atom := stripExponent(term)
+		if ucumAtoms[atom] {
+			continue
+		}
+		if matched := matchUCUMPrefix(atom); matched {
+			continue
+		}
+		return fmt.Errorf("terminology: %q is not a recognized UCUM atom in unit %q", atom, code)
+	}
+	return nil
+}
+
+// splitUCUM splits a UCUM expression on '.' and '/' separators, which
+// UCUM uses in place of '*' and division respectively.
+func splitUCUM(code string) []string {
+	replacer := strings.NewReplacer(".", " ", "/", " ")
+	return strings.Fields(replacer.Replace(code))
+}
+
+// stripExponent strips a trailing exponent (e.g. "m2" -> "m") so the
+// remainder can be looked up in ucumAtoms. Validate tries the unstripped
+// term against ucumAtoms first, since some UCUM atoms (the annotated-
+// number atoms "10*3", "10*6", ...) contain digits themselves and would
+// otherwise be mangled into an unrecognized atom before ever reaching the
+// table.
+func stripExponent(atom string) string {
+	i := len(atom)
+	for i > 0 && (atom[i-1] == '-' || (atom[i-1] >= '0' && atom[i-1] <= '9')) {
+		i--
+	}
+	if i == 0 {
+		return atom
+	}
+	return atom[:i]
+}
+
+func matchUCUMPrefix(atom string) bool {
+	for _, prefix := range ucumPrefixes {
+		if strings.HasPrefix(atom, prefix) && ucumAtoms[strings.TrimPrefix(atom, prefix)] {
+			return true
+		}
+	}
+	return false
+}
+
+// bcp47Pattern is a simplified IETF BCP-47 language tag grammar covering
+// language, optional script, and optional region/variant subtags (e.g.
+// "en", "en-US", "zh-Hans-CN").
+var bcp47Pattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// BCP47Validator offline-validates IETF BCP-47 language tags against a
+// simplified grammar (it does not check subtags against the IANA
+// registry, only their shape).
+type BCP47Validator struct{}
+
+// Validate reports an error if code does not match the BCP-47 tag
+// grammar.
+func (BCP47Validator) Validate(code string) error {
+	if !bcp47Pattern.MatchString(code) {
+		return fmt.Errorf("terminology: %q is not a well-formed BCP-47 language tag", code)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// Package validate enforces the JSON Schemas published alongside the
+// wellally Go types at runtime.
+//
+// Package: https://github.com/huifer/wellally-schemas/health-models
+// Website: https://www.wellally.tech/
+//
+// The schemas themselves are embedded from validate/schemas so that
+// callers do not need network access to validate, and so that
+// cmd/wellally-gen can regenerate the schema package from the same
+// source of truth. Use Validate for wellally values you already have in
+// hand, or ValidateBytes when validating raw JSON (e.g. before
+// unmarshalling it) against a known schema ID.
+//
+// Validation itself is a hand-rolled draft-07 walker (see node in
+// schema.go) covering only the keywords validate/schemas actually uses,
+// not a general-purpose pure-Go JSON Schema library — it is deliberately
+// narrow rather than a full implementation.
+package validate
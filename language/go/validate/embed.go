@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Well-known schema IDs, matching the `Schema:` URL documented on each
+// wellally package file.
+const (
+	SchemaCommon        = "https://wellall.health/schemas/common/v0.1.0"
+	SchemaHealth        = "https://wellall.health/schemas/health/v0.1.0"
+	SchemaLabReport     = "https://wellall.health/schemas/lab-report/v0.1.0"
+	SchemaImagingReport = "https://wellall.health/schemas/imaging-report/v0.1.0"
+	SchemaMedication    = "https://wellall.health/schemas/medication/v0.1.0"
+	SchemaFamilyHealth  = "https://wellall.health/schemas/family-health/v0.1.0"
+)
+
+var schemasByID = map[string]*node{}
+
+func init() {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		panic(fmt.Errorf("validate: reading embedded schemas: %w", err))
+	}
+	for _, entry := range entries {
+		data, err := schemaFS.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			panic(fmt.Errorf("validate: reading %s: %w", entry.Name(), err))
+		}
+		var n node
+		if err := json.Unmarshal(data, &n); err != nil {
+			panic(fmt.Errorf("validate: parsing %s: %w", entry.Name(), err))
+		}
+		if n.ID == "" {
+			panic(fmt.Errorf("validate: %s has no $id", entry.Name()))
+		}
+		n.setRoot(&n)
+		schemasByID[n.ID] = &n
+	}
+}
+
+// schemaIDFor returns the schema ID bound to the concrete type of v.
+func schemaIDFor(v any) (string, error) {
+	switch v.(type) {
+	case wellally.Person, *wellally.Person:
+		return SchemaHealth, nil
+	case wellally.LabReport, *wellally.LabReport:
+		return SchemaLabReport, nil
+	case wellally.ImagingReport, *wellally.ImagingReport:
+		return SchemaImagingReport, nil
+	case wellally.MedicationRecord, *wellally.MedicationRecord:
+		return SchemaMedication, nil
+	case wellally.FamilyHealthTree, *wellally.FamilyHealthTree:
+		return SchemaFamilyHealth, nil
+	default:
+		return "", fmt.Errorf("validate: no schema binding registered for %T", v)
+	}
+}
@@ -0,0 +1,109 @@
+package validate
+
+import "regexp"
+
+// node is a minimal draft-07 JSON Schema document, covering the keywords
+// the wellally schemas actually use.
+type node struct {
+	ID          string           `json:"$id"`
+	Ref         string           `json:"$ref"`
+	Type        string           `json:"type"`
+	Required    []string         `json:"required"`
+	Properties  map[string]*node `json:"properties"`
+	Items       *node            `json:"items"`
+	Enum        []any            `json:"enum"`
+	Pattern     string           `json:"pattern"`
+	Format      string           `json:"format"`
+	MinItems    *int             `json:"minItems"`
+	MinLength   *int             `json:"minLength"`
+	Definitions map[string]*node `json:"definitions"`
+
+	root *node // set by setRoot, used to follow "#/definitions/X" refs
+}
+
+// setRoot propagates the document root through every nested node so that
+// $ref resolution works regardless of nesting depth.
+func (n *node) setRoot(root *node) {
+	n.root = root
+	for _, prop := range n.Properties {
+		prop.setRoot(root)
+	}
+	if n.Items != nil {
+		n.Items.setRoot(root)
+	}
+	for _, def := range n.Definitions {
+		def.setRoot(root)
+	}
+}
+
+func (n *node) resolve() *node {
+	if n.Ref == "" {
+		return n
+	}
+	const prefix = "#/definitions/"
+	root := n.root
+	if root == nil {
+		root = n
+	}
+	if len(n.Ref) > len(prefix) && n.Ref[:len(prefix)] == prefix {
+		if def, ok := root.Definitions[n.Ref[len(prefix):]]; ok {
+			def.root = root
+			return def
+		}
+	}
+	return n
+}
+
+func jsonType(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if val == float64(int64(val)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+var patternCache = map[string]*regexp.Regexp{}
+
+func compiledPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache[pattern] = re
+	return re, nil
+}
+
+var dateRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+var dateTimeRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}`)
+
+func checkFormat(format string, value any) bool {
+	s, ok := value.(string)
+	if !ok {
+		return true
+	}
+	switch format {
+	case "date":
+		return dateRE.MatchString(s)
+	case "date-time":
+		return dateTimeRE.MatchString(s)
+	default:
+		return true
+	}
+}
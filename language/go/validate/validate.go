@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Issue is a single schema violation, located by a JSON pointer (RFC 6901)
+// into the document that was validated. The document root is reported as
+// "/" rather than "", and "~" and "/" inside property names are not
+// escaped.
+type Issue struct {
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// ValidationError reports every Issue found while validating a document
+// against a schema.
+type ValidationError struct {
+	SchemaID string
+	Issues   []Issue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		messages[i] = issue.String()
+	}
+	return fmt.Sprintf("validate: %s: %s", e.SchemaID, strings.Join(messages, "; "))
+}
+
+// Validate marshals v to JSON and checks it against the schema bound to
+// v's concrete type (see schemaIDFor). v must be one of the wellally
+// domain types: Person, LabReport, ImagingReport, MedicationRecord, or
+// FamilyHealthTree.
+func Validate(v any) error {
+	schemaID, err := schemaIDFor(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("validate: marshaling %T: %w", v, err)
+	}
+	return ValidateBytes(schemaID, data)
+}
+
+// ValidateBytes parses data as JSON and checks it against the schema
+// identified by schemaID (one of the Schema* constants). It returns a
+// *ValidationError when the document violates the schema, or an error if
+// schemaID is unknown or data is not valid JSON.
+func ValidateBytes(schemaID string, data []byte) error {
+	schema, ok := schemasByID[schemaID]
+	if !ok {
+		return fmt.Errorf("validate: unknown schema id %q", schemaID)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("validate: parsing document: %w", err)
+	}
+	var issues []Issue
+	collect(schema, doc, "", &issues)
+	if len(issues) > 0 {
+		return &ValidationError{SchemaID: schemaID, Issues: issues}
+	}
+	return nil
+}
+
+func collect(schema *node, value any, path string, issues *[]Issue) {
+	schema = schema.resolve()
+
+	if schema.Type != "" && jsonType(value) != schema.Type {
+		if !(schema.Type == "number" && jsonType(value) == "integer") {
+			*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("expected type %s, got %s", schema.Type, jsonType(value))})
+			return
+		}
+	}
+
+	if len(schema.Enum) > 0 && !containsAny(schema.Enum, value) {
+		*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("value %v is not one of %v", value, schema.Enum)})
+	}
+
+	if schema.Pattern != "" {
+		if s, ok := value.(string); ok && !matchPattern(schema.Pattern, s) {
+			*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("value %q does not match pattern %q", s, schema.Pattern)})
+		}
+	}
+
+	if schema.Format != "" && !checkFormat(schema.Format, value) {
+		*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("value %v does not satisfy format %q", value, schema.Format)})
+	}
+
+	if schema.MinLength != nil {
+		if s, ok := value.(string); ok && len(s) < *schema.MinLength {
+			*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("length %d is below minLength %d", len(s), *schema.MinLength)})
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, required := range schema.Required {
+			if _, present := obj[required]; !present {
+				*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("missing required property %q", required)})
+			}
+		}
+		for name, propSchema := range schema.Properties {
+			if propValue, present := obj[name]; present {
+				collect(propSchema, propValue, path+"/"+name, issues)
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return
+		}
+		if schema.MinItems != nil && len(arr) < *schema.MinItems {
+			*issues = append(*issues, Issue{Path: pointer(path), Message: fmt.Sprintf("item count %d is below minItems %d", len(arr), *schema.MinItems)})
+		}
+		if schema.Items != nil {
+			for i, item := range arr {
+				collect(schema.Items, item, fmt.Sprintf("%s/%d", path, i), issues)
+			}
+		}
+	}
+}
+
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func containsAny(haystack []any, needle any) bool {
+	for _, candidate := range haystack {
+		if fmt.Sprint(candidate) == fmt.Sprint(needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, s string) bool {
+	re, err := compiledPattern(pattern)
+	if err != nil {
+		return true
+	}
+	return re.MatchString(s)
+}
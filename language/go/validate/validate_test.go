@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	wellally "github.com/huifer/wellally-schemas/language/go"
+)
+
+// validCases builds one valid value per core wellally type, so Validate
+// is exercised against every schema binding in schemaIDFor.
+func validCases() map[string]any {
+	return map[string]any{
+		"Person": wellally.Person{
+			ID:        "person-1",
+			Name:      []wellally.HumanName{{Family: "Doe", Given: []string{"Jane"}}},
+			BirthDate: time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC),
+		},
+		"LabReport": wellally.LabReport{
+			ID:        "lab-1",
+			PatientID: "person-1",
+			IssuedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Results: []wellally.LabResult{
+				{Code: wellally.CodeableConcept{Coding: []wellally.Coding{{System: "http://loinc.org", Code: "2345-7"}}}, Value: "Positive"},
+			},
+		},
+		"ImagingReport": wellally.ImagingReport{
+			ID:         "img-1",
+			PatientID:  "person-1",
+			Modality:   wellally.Modality{System: "http://dicom.nema.org/resources/ontology/DCM", Code: wellally.ModalityCodeCT},
+			BodySite:   wellally.Coding{System: "http://snomed.info/sct", Code: "51185008"},
+			ReportedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"MedicationRecord": wellally.MedicationRecord{
+			ID:         "med-1",
+			PatientID:  "person-1",
+			Medication: wellally.Coding{System: "http://www.nlm.nih.gov/research/umls/rxnorm", Code: "860975"},
+			Dosage:     wellally.Dosage{Value: 500, Unit: "mg"},
+			Route:      wellally.Route{System: "http://terminology.hl7.org/CodeSystem/v3-RouteOfAdministration", Code: "PO"},
+			StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"FamilyHealthTree": wellally.FamilyHealthTree{
+			ProbandID: "person-1",
+			Members: []wellally.FamilyMember{
+				{ID: "member-1", RelationToProband: wellally.RelationMother},
+			},
+		},
+	}
+}
+
+// TestValidateCoreTypes checks that a minimally valid value of each core
+// wellally type passes Validate against its bound schema, so a schema/
+// struct mismatch like the birthDate format or a missing omitempty is
+// caught here instead of at a caller's runtime.
+func TestValidateCoreTypes(t *testing.T) {
+	for name, v := range validCases() {
+		t.Run(name, func(t *testing.T) {
+			if err := Validate(v); err != nil {
+				t.Errorf("Validate(%s): %v", name, err)
+			}
+		})
+	}
+}
+
+// TestValidatePersonWithoutOptionalFields checks that a Person carrying
+// none of its optional fields (resourceType, gender, ...) still validates
+// — resourceType must be marshaled with omitempty, not emitted as "", or
+// the schema's enum check rejects it.
+func TestValidatePersonWithoutOptionalFields(t *testing.T) {
+	p := wellally.Person{
+		ID:        "person-2",
+		Name:      []wellally.HumanName{{Family: "Doe", Given: []string{"John"}}},
+		BirthDate: time.Date(1985, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+	if err := Validate(p); err != nil {
+		t.Errorf("Validate(Person without resourceType): %v", err)
+	}
+}